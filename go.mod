@@ -32,7 +32,7 @@ require (
 	go.uber.org/atomic v1.5.1 // indirect
 	go.uber.org/multierr v1.4.0 // indirect
 	go.uber.org/zap v1.13.0
-	golang.org/x/crypto v0.0.0-20200221231518-2aa609cf4a9d // indirect
+	golang.org/x/crypto v0.0.0-20200221231518-2aa609cf4a9d
 	golang.org/x/net v0.0.0-20200225223329-5d076fcf07a8 // indirect
 	golang.org/x/text v0.3.2
 	golang.org/x/tools v0.0.0-20200225230052-807dcd883420 // indirect
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE characters
+    DROP COLUMN scenariodata;
+
+END;
\ No newline at end of file
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE characters
+    ADD COLUMN scenariodata bytea;
+
+END;
\ No newline at end of file
@@ -0,0 +1,19 @@
+BEGIN;
+
+CREATE TABLE quest_logs
+(
+    id           serial    NOT NULL PRIMARY KEY,
+    character_id int       NOT NULL REFERENCES characters (id),
+    quest_id     int       NOT NULL,
+    clear_time   int       NOT NULL,
+    weapon       uint16    NOT NULL,
+    party        int[]     NOT NULL DEFAULT '{}',
+    kills        bytea,
+    record       bytea,
+    created_at   timestamp NOT NULL DEFAULT now()
+);
+
+CREATE INDEX quest_logs_character_id_index ON quest_logs (character_id, created_at DESC);
+CREATE INDEX quest_logs_quest_id_clear_time_index ON quest_logs (quest_id, clear_time);
+
+END;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE terminal_logs;
+DROP TABLE terminal_log_sessions;
+
+END;
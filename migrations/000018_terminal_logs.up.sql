@@ -0,0 +1,29 @@
+BEGIN;
+
+CREATE TABLE terminal_log_sessions
+(
+    id           serial    NOT NULL PRIMARY KEY,
+    character_id int       NOT NULL REFERENCES characters (id),
+    created_at   timestamp NOT NULL DEFAULT now()
+);
+
+CREATE TABLE terminal_logs
+(
+    id           serial    NOT NULL PRIMARY KEY,
+    log_id       int       NOT NULL REFERENCES terminal_log_sessions (id) ON DELETE CASCADE,
+    character_id int       NOT NULL REFERENCES characters (id),
+    u0           bigint    NOT NULL,
+    u1           bigint    NOT NULL,
+    u2           bigint    NOT NULL,
+    u3           bigint    NOT NULL,
+    u4           bigint    NOT NULL,
+    u5           bigint    NOT NULL,
+    u6           bigint    NOT NULL,
+    u7           bigint    NOT NULL,
+    u8           bigint    NOT NULL,
+    created_at   timestamp NOT NULL DEFAULT now()
+);
+
+CREATE INDEX terminal_logs_character_id_index ON terminal_logs (character_id, created_at DESC);
+
+END;
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE campaign_entries;
+DROP TABLE campaigns;
+DROP TABLE events;
+
+END;
@@ -0,0 +1,31 @@
+BEGIN;
+
+CREATE TABLE events
+(
+    id         serial    NOT NULL PRIMARY KEY,
+    event_type uint16    NOT NULL,
+    start_time timestamp NOT NULL,
+    end_time   timestamp NOT NULL
+);
+
+CREATE TABLE campaigns
+(
+    id          serial    NOT NULL PRIMARY KEY,
+    title       text      NOT NULL,
+    description text      NOT NULL DEFAULT '',
+    code        text,
+    min_hr      uint16    NOT NULL DEFAULT 0,
+    max_hr      uint16    NOT NULL DEFAULT 999,
+    start_time  timestamp NOT NULL,
+    end_time    timestamp NOT NULL
+);
+
+CREATE TABLE campaign_entries
+(
+    campaign_id  int       NOT NULL REFERENCES campaigns (id) ON DELETE CASCADE,
+    character_id int       NOT NULL REFERENCES characters (id),
+    applied_at   timestamp NOT NULL DEFAULT now(),
+    PRIMARY KEY (campaign_id, character_id)
+);
+
+END;
@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP TABLE distributions_accepted;
+DROP TABLE distribution_items;
+DROP TABLE distribution_targets;
+DROP TABLE distributions;
+
+END;
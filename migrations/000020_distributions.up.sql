@@ -0,0 +1,40 @@
+BEGIN;
+
+CREATE TABLE distributions
+(
+    id               serial    NOT NULL PRIMARY KEY,
+    name             text      NOT NULL,
+    description      text      NOT NULL DEFAULT '',
+    times_acceptable uint16    NOT NULL DEFAULT 1,
+    start_time       timestamp NOT NULL DEFAULT now(),
+    deadline         timestamp
+);
+
+-- Distributions without targets are available to every character
+CREATE TABLE distribution_targets
+(
+    distribution_id int NOT NULL REFERENCES distributions (id) ON DELETE CASCADE,
+    character_id    int NOT NULL REFERENCES characters (id),
+    PRIMARY KEY (distribution_id, character_id)
+);
+
+CREATE TABLE distribution_items
+(
+    id              serial NOT NULL PRIMARY KEY,
+    distribution_id int    NOT NULL REFERENCES distributions (id) ON DELETE CASCADE,
+    item_type       uint8  NOT NULL CHECK (item_type <= 31),
+    item_id         uint16 NOT NULL DEFAULT 0,
+    quantity        uint16 NOT NULL DEFAULT 1
+);
+
+CREATE TABLE distributions_accepted
+(
+    id              serial    NOT NULL PRIMARY KEY,
+    distribution_id int       NOT NULL REFERENCES distributions (id) ON DELETE CASCADE,
+    character_id    int       NOT NULL REFERENCES characters (id),
+    accepted_at     timestamp NOT NULL DEFAULT now()
+);
+
+CREATE INDEX distributions_accepted_character_id_index ON distributions_accepted (character_id, distribution_id);
+
+END;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE warehouse_boxes;
+DROP TABLE warehouse;
+
+END;
@@ -0,0 +1,21 @@
+BEGIN;
+
+CREATE TABLE warehouse
+(
+    character_id int    NOT NULL PRIMARY KEY REFERENCES characters (id),
+    item_pages   uint8  NOT NULL DEFAULT 1,
+    equip_pages  uint8  NOT NULL DEFAULT 1,
+    item_names   text[] NOT NULL DEFAULT '{}',
+    equip_names  text[] NOT NULL DEFAULT '{}'
+);
+
+CREATE TABLE warehouse_boxes
+(
+    character_id int   NOT NULL REFERENCES characters (id),
+    box_type     uint8 NOT NULL,
+    box_index    uint8 NOT NULL,
+    data         bytea NOT NULL,
+    PRIMARY KEY (character_id, box_type, box_index)
+);
+
+END;
@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE characters
+    DROP COLUMN house_tier,
+    DROP COLUMN house_data,
+    DROP COLUMN house_furniture,
+    DROP COLUMN house_state,
+    DROP COLUMN house_password;
+
+END;
@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE characters
+    ADD COLUMN house_tier bytea,
+    ADD COLUMN house_data bytea,
+    ADD COLUMN house_furniture bytea,
+    ADD COLUMN house_state uint8 NOT NULL DEFAULT 1,
+    ADD COLUMN house_password text NOT NULL DEFAULT '';
+
+END;
@@ -0,0 +1,12 @@
+BEGIN;
+
+CREATE TABLE titles
+(
+    character_id int       NOT NULL REFERENCES characters (id),
+    title_id     uint16    NOT NULL,
+    acquired_at  timestamp NOT NULL DEFAULT now(),
+    updated_at   timestamp NOT NULL DEFAULT now(),
+    PRIMARY KEY (character_id, title_id)
+);
+
+END;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE achievement_rewards;
+DROP TABLE achievements;
+
+END;
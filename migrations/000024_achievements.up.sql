@@ -0,0 +1,25 @@
+BEGIN;
+
+CREATE TABLE achievements
+(
+    character_id   int    NOT NULL REFERENCES characters (id),
+    achievement_id uint8  NOT NULL,
+    progress       int    NOT NULL DEFAULT 0,
+    displayed_rank uint8  NOT NULL DEFAULT 0,
+    paid_rank      uint8  NOT NULL DEFAULT 0,
+    PRIMARY KEY (character_id, achievement_id)
+);
+
+-- Rewards are handed out as a distribution once a rank has been paid
+CREATE TABLE achievement_rewards
+(
+    achievement_id uint8  NOT NULL,
+    rank           uint8  NOT NULL,
+    item_type      uint8  NOT NULL DEFAULT 7 CHECK (item_type <= 31),
+    item_id        uint16 NOT NULL DEFAULT 0,
+    quantity       uint16 NOT NULL DEFAULT 1
+);
+
+CREATE INDEX achievement_rewards_achievement_id_index ON achievement_rewards (achievement_id, rank);
+
+END;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE characters
+    DROP COLUMN guild_card;
+
+END;
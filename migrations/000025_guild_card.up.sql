@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE characters
+    ADD COLUMN guild_card bytea;
+
+END;
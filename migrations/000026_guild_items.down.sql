@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE guild_item_logs;
+DROP TABLE guild_items;
+
+END;
@@ -0,0 +1,25 @@
+BEGIN;
+
+CREATE TABLE guild_items
+(
+    id       serial NOT NULL PRIMARY KEY,
+    guild_id int    NOT NULL REFERENCES guilds (id) ON DELETE CASCADE,
+    item_id  uint16 NOT NULL,
+    quantity uint16 NOT NULL
+);
+
+CREATE INDEX guild_items_guild_id_index ON guild_items (guild_id);
+
+CREATE TABLE guild_item_logs
+(
+    id           serial    NOT NULL PRIMARY KEY,
+    guild_id     int       NOT NULL REFERENCES guilds (id) ON DELETE CASCADE,
+    character_id int       NOT NULL REFERENCES characters (id),
+    item_id      uint16    NOT NULL,
+    quantity     int       NOT NULL,
+    created_at   timestamp NOT NULL DEFAULT now()
+);
+
+CREATE INDEX guild_item_logs_guild_id_index ON guild_item_logs (guild_id, created_at DESC);
+
+END;
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE guild_alliance_invitations;
+DROP TABLE guild_alliance_members;
+DROP TABLE guild_alliances;
+
+END;
@@ -0,0 +1,28 @@
+BEGIN;
+
+CREATE TABLE guild_alliances
+(
+    id         serial      NOT NULL PRIMARY KEY,
+    name       varchar(24) NOT NULL,
+    created_at timestamp   NOT NULL DEFAULT now(),
+    parent_id  int         NOT NULL UNIQUE REFERENCES guilds (id) ON DELETE CASCADE
+);
+
+CREATE TABLE guild_alliance_members
+(
+    alliance_id int       NOT NULL REFERENCES guild_alliances (id) ON DELETE CASCADE,
+    guild_id    int       NOT NULL UNIQUE REFERENCES guilds (id) ON DELETE CASCADE,
+    joined_at   timestamp NOT NULL DEFAULT now()
+);
+
+CREATE INDEX guild_alliance_members_alliance_id_index ON guild_alliance_members (alliance_id);
+
+CREATE TABLE guild_alliance_invitations
+(
+    alliance_id int       NOT NULL REFERENCES guild_alliances (id) ON DELETE CASCADE,
+    guild_id    int       NOT NULL REFERENCES guilds (id) ON DELETE CASCADE,
+    created_at  timestamp NOT NULL DEFAULT now(),
+    PRIMARY KEY (alliance_id, guild_id)
+);
+
+END;
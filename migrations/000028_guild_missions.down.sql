@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE guild_mission_targets;
+DROP TABLE guild_missions;
+
+END;
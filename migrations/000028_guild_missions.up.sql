@@ -0,0 +1,24 @@
+BEGIN;
+
+CREATE TABLE guild_missions
+(
+    id         serial NOT NULL PRIMARY KEY,
+    text_id    int    NOT NULL,
+    target_id  int    NOT NULL,
+    quantity   uint16 NOT NULL,
+    difficulty uint8  NOT NULL DEFAULT 1,
+    category   uint8  NOT NULL DEFAULT 1,
+    reward_rp  uint16 NOT NULL DEFAULT 0
+);
+
+CREATE TABLE guild_mission_targets
+(
+    guild_id     int       NOT NULL REFERENCES guilds (id) ON DELETE CASCADE,
+    mission_id   int       NOT NULL REFERENCES guild_missions (id) ON DELETE CASCADE,
+    progress     int       NOT NULL DEFAULT 0,
+    set_at       timestamp NOT NULL DEFAULT now(),
+    completed_at timestamp,
+    PRIMARY KEY (guild_id, mission_id)
+);
+
+END;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE guild_hunt_members;
+DROP TABLE guild_hunts;
+
+END;
@@ -0,0 +1,25 @@
+BEGIN;
+
+CREATE TABLE guild_hunts
+(
+    id           serial    NOT NULL PRIMARY KEY,
+    guild_id     int       NOT NULL REFERENCES guilds (id) ON DELETE CASCADE,
+    host_id      int       NOT NULL REFERENCES characters (id),
+    destination  int       NOT NULL,
+    level        int       NOT NULL,
+    hunt_data    bytea     NOT NULL,
+    created_at   timestamp NOT NULL DEFAULT now(),
+    completed_at timestamp
+);
+
+CREATE INDEX guild_hunts_guild_id_index ON guild_hunts (guild_id, created_at DESC);
+
+CREATE TABLE guild_hunt_members
+(
+    hunt_id      int     NOT NULL REFERENCES guild_hunts (id) ON DELETE CASCADE,
+    character_id int     NOT NULL REFERENCES characters (id),
+    claimed      boolean NOT NULL DEFAULT false,
+    PRIMARY KEY (hunt_id, character_id)
+);
+
+END;
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE guild_adventure_claims;
+DROP TABLE guild_adventures;
+DROP TABLE guild_meals;
+
+END;
@@ -0,0 +1,35 @@
+BEGIN;
+
+CREATE TABLE guild_meals
+(
+    id         serial    NOT NULL PRIMARY KEY,
+    guild_id   int       NOT NULL REFERENCES guilds (id) ON DELETE CASCADE,
+    meal_id    uint16    NOT NULL,
+    level      uint8     NOT NULL,
+    creator_id int       NOT NULL REFERENCES characters (id),
+    created_at timestamp NOT NULL DEFAULT now()
+);
+
+CREATE INDEX guild_meals_guild_id_index ON guild_meals (guild_id, created_at DESC);
+
+CREATE TABLE guild_adventures
+(
+    id          serial    NOT NULL PRIMARY KEY,
+    guild_id    int       NOT NULL REFERENCES guilds (id) ON DELETE CASCADE,
+    destination int       NOT NULL,
+    charge      int       NOT NULL DEFAULT 0,
+    departed_at timestamp NOT NULL DEFAULT now(),
+    returns_at  timestamp NOT NULL
+);
+
+CREATE INDEX guild_adventures_guild_id_index ON guild_adventures (guild_id);
+
+CREATE TABLE guild_adventure_claims
+(
+    adventure_id int       NOT NULL REFERENCES guild_adventures (id) ON DELETE CASCADE,
+    character_id int       NOT NULL REFERENCES characters (id),
+    claimed_at   timestamp NOT NULL DEFAULT now(),
+    PRIMARY KEY (adventure_id, character_id)
+);
+
+END;
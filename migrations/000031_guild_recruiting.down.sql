@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP INDEX guilds_created_at_index;
+
+ALTER TABLE guilds
+    DROP COLUMN recruiting;
+
+END;
@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE guilds
+    ADD COLUMN recruiting boolean NOT NULL DEFAULT true;
+
+CREATE INDEX guilds_created_at_index ON guilds (created_at DESC);
+
+END;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE guild_characters
+    DROP COLUMN recruiter;
+
+END;
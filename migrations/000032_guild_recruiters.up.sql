@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE guild_characters
+    ADD COLUMN recruiter boolean NOT NULL DEFAULT false;
+
+END;
@@ -0,0 +1,12 @@
+BEGIN;
+
+ALTER TABLE guilds
+    DROP COLUMN pugi_name_1,
+    DROP COLUMN pugi_name_2,
+    DROP COLUMN pugi_name_3,
+    DROP COLUMN pugi_outfit_1,
+    DROP COLUMN pugi_outfit_2,
+    DROP COLUMN pugi_outfit_3,
+    DROP COLUMN pugi_outfits;
+
+END;
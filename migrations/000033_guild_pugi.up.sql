@@ -0,0 +1,12 @@
+BEGIN;
+
+ALTER TABLE guilds
+    ADD COLUMN pugi_name_1 text NOT NULL DEFAULT '',
+    ADD COLUMN pugi_name_2 text NOT NULL DEFAULT '',
+    ADD COLUMN pugi_name_3 text NOT NULL DEFAULT '',
+    ADD COLUMN pugi_outfit_1 uint8 NOT NULL DEFAULT 4,
+    ADD COLUMN pugi_outfit_2 uint8 NOT NULL DEFAULT 2,
+    ADD COLUMN pugi_outfit_3 uint8 NOT NULL DEFAULT 3,
+    ADD COLUMN pugi_outfits integer NOT NULL DEFAULT 78;
+
+END;
@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP TABLE guild_weekly_bonus;
+DROP TABLE guild_weekly_activity;
+
+ALTER TABLE guild_characters
+    DROP COLUMN last_active_at;
+
+END;
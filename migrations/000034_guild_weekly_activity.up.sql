@@ -0,0 +1,24 @@
+BEGIN;
+
+ALTER TABLE guild_characters
+    ADD COLUMN last_active_at timestamp;
+
+CREATE TABLE guild_weekly_activity
+(
+    guild_id     int       NOT NULL REFERENCES guilds (id) ON DELETE CASCADE,
+    character_id int       NOT NULL REFERENCES characters (id) ON DELETE CASCADE,
+    week_start   date      NOT NULL,
+    logins       int       NOT NULL DEFAULT 0,
+    quests       int       NOT NULL DEFAULT 0,
+    PRIMARY KEY (guild_id, character_id, week_start)
+);
+
+CREATE TABLE guild_weekly_bonus
+(
+    guild_id          int  NOT NULL REFERENCES guilds (id) ON DELETE CASCADE,
+    week_start        date NOT NULL,
+    exceptional_users int  NOT NULL DEFAULT 0,
+    PRIMARY KEY (guild_id, week_start)
+);
+
+END;
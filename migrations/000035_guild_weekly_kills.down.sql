@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE guild_characters
+    DROP COLUMN hunt_data_claimed_week;
+
+DROP TABLE guild_weekly_kills;
+
+END;
@@ -0,0 +1,15 @@
+BEGIN;
+
+CREATE TABLE guild_weekly_kills
+(
+    guild_id   int  NOT NULL REFERENCES guilds (id) ON DELETE CASCADE,
+    week_start date NOT NULL,
+    monster_id int  NOT NULL,
+    kills      int  NOT NULL DEFAULT 0,
+    PRIMARY KEY (guild_id, week_start, monster_id)
+);
+
+ALTER TABLE guild_characters
+    ADD COLUMN hunt_data_claimed_week date;
+
+END;
@@ -0,0 +1,8 @@
+BEGIN;
+
+DELETE FROM mail WHERE sender_id IS NULL;
+
+ALTER TABLE mail
+    ALTER COLUMN sender_id SET NOT NULL;
+
+END;
@@ -0,0 +1,7 @@
+BEGIN;
+
+-- Mail without a sender comes from the server itself.
+ALTER TABLE mail
+    ALTER COLUMN sender_id DROP NOT NULL;
+
+END;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE character_blacklist;
+DROP TABLE character_friends;
+
+END;
@@ -0,0 +1,21 @@
+BEGIN;
+
+CREATE TABLE character_friends
+(
+    character_id int       NOT NULL REFERENCES characters (id) ON DELETE CASCADE,
+    friend_id    int       NOT NULL REFERENCES characters (id) ON DELETE CASCADE,
+    created_at   timestamp NOT NULL DEFAULT now(),
+    PRIMARY KEY (character_id, friend_id)
+);
+
+CREATE TABLE character_blacklist
+(
+    character_id int       NOT NULL REFERENCES characters (id) ON DELETE CASCADE,
+    blocked_id   int       NOT NULL REFERENCES characters (id) ON DELETE CASCADE,
+    created_at   timestamp NOT NULL DEFAULT now(),
+    PRIMARY KEY (character_id, blocked_id)
+);
+
+CREATE INDEX character_blacklist_blocked_id_index ON character_blacklist (blocked_id);
+
+END;
@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE users
+    DROP COLUMN role;
+
+DROP TYPE user_role;
+
+END;
@@ -0,0 +1,8 @@
+BEGIN;
+
+CREATE TYPE user_role AS ENUM ('player', 'moderator', 'admin');
+
+ALTER TABLE users
+    ADD COLUMN role user_role NOT NULL DEFAULT 'player';
+
+END;
@@ -0,0 +1,5 @@
+BEGIN;
+
+-- The previous last_login values can't be restored.
+
+END;
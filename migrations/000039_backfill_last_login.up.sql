@@ -0,0 +1,7 @@
+BEGIN;
+
+-- last_login was only set when a character was created, so every character starts over from now
+-- instead of looking like it has been away since creation.
+UPDATE characters SET last_login = extract(epoch FROM now())::int;
+
+END;
@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE distributions_accepted
+    DROP CONSTRAINT distributions_accepted_claim_key,
+    DROP COLUMN claim;
+
+END;
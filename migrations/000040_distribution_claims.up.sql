@@ -0,0 +1,19 @@
+BEGIN;
+
+-- Numbering each character's claims lets the unique constraint reject concurrent claims over the limit.
+ALTER TABLE distributions_accepted
+    ADD COLUMN claim int;
+
+UPDATE distributions_accepted da
+SET claim = numbered.claim
+FROM (
+    SELECT id, row_number() OVER (PARTITION BY distribution_id, character_id ORDER BY accepted_at, id) AS claim
+    FROM distributions_accepted
+) numbered
+WHERE numbered.id = da.id;
+
+ALTER TABLE distributions_accepted
+    ALTER COLUMN claim SET NOT NULL,
+    ADD CONSTRAINT distributions_accepted_claim_key UNIQUE (distribution_id, character_id, claim);
+
+END;
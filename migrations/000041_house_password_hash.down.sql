@@ -0,0 +1,11 @@
+BEGIN;
+
+-- Hashed passwords can't be recovered, houses lose their password instead.
+UPDATE characters
+SET house_password_hash = ''
+WHERE house_password_hash != '';
+
+ALTER TABLE characters
+    RENAME COLUMN house_password_hash TO house_password;
+
+END;
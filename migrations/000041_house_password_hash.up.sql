@@ -0,0 +1,13 @@
+BEGIN;
+
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
+
+-- House passwords are stored as bcrypt hashes, an empty hash meaning the house has no password.
+ALTER TABLE characters
+    RENAME COLUMN house_password TO house_password_hash;
+
+UPDATE characters
+SET house_password_hash = crypt(house_password_hash, gen_salt('bf'))
+WHERE house_password_hash != '';
+
+END;
@@ -0,0 +1,18 @@
+BEGIN;
+
+CREATE TABLE guild_weekly_bonus
+(
+    guild_id          int  NOT NULL REFERENCES guilds (id) ON DELETE CASCADE,
+    week_start        date NOT NULL,
+    exceptional_users int  NOT NULL DEFAULT 0,
+    PRIMARY KEY (guild_id, week_start)
+);
+
+INSERT INTO guild_weekly_bonus (guild_id, week_start, exceptional_users)
+SELECT guild_id, week_start, COUNT(*)
+FROM guild_weekly_exceptional_users
+GROUP BY guild_id, week_start;
+
+DROP TABLE guild_weekly_exceptional_users;
+
+END;
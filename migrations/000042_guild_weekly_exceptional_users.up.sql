@@ -0,0 +1,14 @@
+BEGIN;
+
+-- Exceptional users are recorded per reporting member so each member is only counted once a week.
+CREATE TABLE guild_weekly_exceptional_users
+(
+    guild_id     int  NOT NULL REFERENCES guilds (id) ON DELETE CASCADE,
+    character_id int  NOT NULL REFERENCES characters (id) ON DELETE CASCADE,
+    week_start   date NOT NULL,
+    PRIMARY KEY (guild_id, character_id, week_start)
+);
+
+DROP TABLE guild_weekly_bonus;
+
+END;
@@ -0,0 +1,448 @@
+package mhfpacket
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Andoryuuta/Erupe/network"
+	"github.com/Andoryuuta/byteframe"
+)
+
+// parsePacketGroup parses a single packet followed by MSG_SYS_END, the way the channel server reads a packet group,
+// and checks the parser left exactly the end marker unread.
+func parsePacketGroup(t *testing.T, opcode network.PacketID, payload []byte) MHFPacket {
+	t.Helper()
+
+	bf := byteframe.NewByteFrame()
+	bf.WriteUint16(uint16(opcode))
+	bf.WriteBytes(payload)
+	bf.WriteUint16(uint16(network.MSG_SYS_END))
+
+	group := byteframe.NewByteFrameFromBytes(bf.Data())
+
+	if got := network.PacketID(group.ReadUint16()); got != opcode {
+		t.Fatalf("got opcode %s, want %s", got, opcode)
+	}
+
+	pkt := FromOpcode(opcode)
+
+	if pkt == nil {
+		t.Fatalf("no packet for opcode %s", opcode)
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				t.Fatalf("parsing %s panicked: %v", opcode, r)
+			}
+		}()
+
+		if err := pkt.Parse(group); err != nil {
+			t.Fatalf("parsing %s: got error %v, want nil", opcode, err)
+		}
+	}()
+
+	end := []byte{byte(network.MSG_SYS_END >> 8), byte(network.MSG_SYS_END)}
+
+	if remaining := group.DataFromCurrent(); !bytes.Equal(remaining, end) {
+		t.Fatalf("parsing %s left % X unread, want the end marker % X", opcode, remaining, end)
+	}
+
+	return pkt
+}
+
+// parsePacket parses a packet on its own, for packets that read everything left in the group.
+func parsePacket(t *testing.T, opcode network.PacketID, payload []byte) MHFPacket {
+	t.Helper()
+
+	pkt := FromOpcode(opcode)
+
+	if pkt == nil {
+		t.Fatalf("no packet for opcode %s", opcode)
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				t.Fatalf("parsing %s panicked: %v", opcode, r)
+			}
+		}()
+
+		if err := pkt.Parse(byteframe.NewByteFrameFromBytes(payload)); err != nil {
+			t.Fatalf("parsing %s: got error %v, want nil", opcode, err)
+		}
+	}()
+
+	return pkt
+}
+
+func TestParseReadGuildcard(t *testing.T) {
+	pkt := parsePacketGroup(
+		t,
+		network.MSG_MHF_READ_GUILDCARD,
+		[]byte{0x00, 0x00, 0x12, 0x34, 0x00, 0x00, 0x00, 0x2A},
+	).(*MsgMhfReadGuildcard)
+
+	if pkt.AckHandle != 0x1234 || pkt.CharID != 42 {
+		t.Errorf("got ack handle 0x%X, character %d, want 0x1234, 42", pkt.AckHandle, pkt.CharID)
+	}
+}
+
+func TestParseUpdateGuildcard(t *testing.T) {
+	pkt := parsePacketGroup(
+		t,
+		network.MSG_MHF_UPDATE_GUILDCARD,
+		[]byte{0x00, 0x00, 0x00, 0x01, 0x00, 0x03, 0xAA, 0xBB, 0xCC},
+	).(*MsgMhfUpdateGuildcard)
+
+	if pkt.AckHandle != 1 {
+		t.Errorf("got ack handle %d, want 1", pkt.AckHandle)
+	}
+
+	if !bytes.Equal(pkt.RawDataPayload, []byte{0xAA, 0xBB, 0xCC}) {
+		t.Errorf("got payload % X, want AA BB CC", pkt.RawDataPayload)
+	}
+}
+
+func TestParseAcquireTitle(t *testing.T) {
+	pkt := parsePacketGroup(
+		t,
+		network.MSG_MHF_ACQUIRE_TITLE,
+		[]byte{0x00, 0x00, 0x00, 0x02, 0x00, 0x02, 0x00, 0x00, 0x00, 0x10, 0x01, 0x20},
+	).(*MsgMhfAcquireTitle)
+
+	if pkt.AckHandle != 2 {
+		t.Errorf("got ack handle %d, want 2", pkt.AckHandle)
+	}
+
+	if len(pkt.TitleIDs) != 2 || pkt.TitleIDs[0] != 0x10 || pkt.TitleIDs[1] != 0x120 {
+		t.Errorf("got title IDs %v, want [16 288]", pkt.TitleIDs)
+	}
+}
+
+func TestParseEnumerateTitle(t *testing.T) {
+	pkt := parsePacketGroup(
+		t,
+		network.MSG_MHF_ENUMERATE_TITLE,
+		[]byte{0x00, 0x00, 0x00, 0x03, 0x00, 0x01, 0x00, 0x05},
+	).(*MsgMhfEnumerateTitle)
+
+	if pkt.AckHandle != 3 || pkt.CharID != 0x10005 {
+		t.Errorf("got ack handle %d and character %d, want 3 and %d", pkt.AckHandle, pkt.CharID, 0x10005)
+	}
+}
+
+func TestParseOperateJoint(t *testing.T) {
+	tests := []struct {
+		payload    []byte
+		wantAction OperateJointAction
+		wantData   []byte
+	}{
+		{
+			[]byte{0x00, 0x00, 0x00, 0x04, 0x00, 0x00, 0x00, 0x07, 0x00, 0x00, 0x00, 0x08, 0x02, 0x00, 0x00, 0x00, 0x09},
+			OPERATE_JOINT_INVITE,
+			[]byte{0x00, 0x00, 0x00, 0x09},
+		},
+		{
+			[]byte{0x00, 0x00, 0x00, 0x04, 0x00, 0x00, 0x00, 0x07, 0x00, 0x00, 0x00, 0x08, 0x01},
+			OPERATE_JOINT_DISBAND,
+			[]byte{},
+		},
+	}
+
+	for _, tt := range tests {
+		pkt := parsePacket(t, network.MSG_MHF_OPERATE_JOINT, tt.payload).(*MsgMhfOperateJoint)
+
+		if pkt.AckHandle != 4 || pkt.AllianceID != 7 || pkt.GuildID != 8 {
+			t.Errorf("got ack handle %d, alliance %d, guild %d, want 4, 7, 8", pkt.AckHandle, pkt.AllianceID, pkt.GuildID)
+		}
+
+		if pkt.Action != tt.wantAction || !bytes.Equal(pkt.UnkData, tt.wantData) {
+			t.Errorf("got action %d with data % X, want %d with % X", pkt.Action, pkt.UnkData, tt.wantAction, tt.wantData)
+		}
+	}
+}
+
+func TestParseSendMail(t *testing.T) {
+	payload := []byte{
+		0x00, 0x00, 0x00, 0x05, // Ack handle
+		0x00, 0x00, 0x01, 0x00, // Recipient
+		0x00, 0x03, // Subject length
+		0x00, 0x02, // Body length
+		0x00, 0x00, 0x00, 0x0A, // Quantity
+		0x00, 0x2A, // Item
+		'H', 'i', 0x00,
+		'!', 0x00,
+	}
+
+	pkt := parsePacketGroup(t, network.MSG_MHF_SEND_MAIL, payload).(*MsgMhfSendMail)
+
+	if pkt.AckHandle != 5 || pkt.RecipientID != 0x100 {
+		t.Errorf("got ack handle %d and recipient %d, want 5 and %d", pkt.AckHandle, pkt.RecipientID, 0x100)
+	}
+
+	if pkt.Quantity != 10 || pkt.ItemID != 42 {
+		t.Errorf("got %d of item %d, want 10 of item 42", pkt.Quantity, pkt.ItemID)
+	}
+
+	if string(pkt.Subject) != "Hi\x00" || string(pkt.Body) != "!\x00" {
+		t.Errorf("got subject %q and body %q, want \"Hi\\x00\" and \"!\\x00\"", pkt.Subject, pkt.Body)
+	}
+}
+
+func TestParseOprMember(t *testing.T) {
+	tests := []struct {
+		payload       []byte
+		wantBlacklist bool
+		wantRemove    bool
+		wantCharIDs   []uint32
+	}{
+		{
+			[]byte{0x00, 0x00, 0x00, 0x06, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x0B},
+			false, false, []uint32{11},
+		},
+		{
+			[]byte{0x00, 0x00, 0x00, 0x06, 0x01, 0x01, 0x00, 0x02, 0x00, 0x00, 0x00, 0x0B, 0x00, 0x00, 0x01, 0x00},
+			true, true, []uint32{11, 0x100},
+		},
+		{
+			[]byte{0x00, 0x00, 0x00, 0x06, 0x00, 0x01, 0x00, 0x00},
+			false, true, nil,
+		},
+	}
+
+	for _, tt := range tests {
+		pkt := parsePacketGroup(t, network.MSG_MHF_OPR_MEMBER, tt.payload).(*MsgMhfOprMember)
+
+		if pkt.AckHandle != 6 || pkt.Blacklist != tt.wantBlacklist || pkt.Operation != tt.wantRemove {
+			t.Errorf(
+				"got ack handle %d, blacklist %v, remove %v, want 6, %v, %v",
+				pkt.AckHandle, pkt.Blacklist, pkt.Operation, tt.wantBlacklist, tt.wantRemove,
+			)
+		}
+
+		if len(pkt.CharIDs) != len(tt.wantCharIDs) {
+			t.Fatalf("got characters %v, want %v", pkt.CharIDs, tt.wantCharIDs)
+		}
+
+		for i := range pkt.CharIDs {
+			if pkt.CharIDs[i] != tt.wantCharIDs[i] {
+				t.Errorf("got characters %v, want %v", pkt.CharIDs, tt.wantCharIDs)
+			}
+		}
+	}
+}
+
+func TestParseApplyCampaign(t *testing.T) {
+	payload := []byte{0x00, 0x00, 0x00, 0x07, 0x00, 0x00, 0x00, 0x02, 0x00, 0x00}
+	payload = append(payload, []byte("CODE\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00")...)
+
+	pkt := parsePacketGroup(t, network.MSG_MHF_APPLY_CAMPAIGN, payload).(*MsgMhfApplyCampaign)
+
+	if pkt.AckHandle != 7 || pkt.CampaignID != 2 {
+		t.Errorf("got ack handle %d and campaign %d, want 7 and 2", pkt.AckHandle, pkt.CampaignID)
+	}
+
+	if len(pkt.Code) != 16 || string(pkt.Code[:5]) != "CODE\x00" {
+		t.Errorf("got code %q, want a 16 byte null terminated \"CODE\"", pkt.Code)
+	}
+}
+
+func TestParseStateCampaign(t *testing.T) {
+	pkt := parsePacketGroup(
+		t,
+		network.MSG_MHF_STATE_CAMPAIGN,
+		[]byte{0x00, 0x00, 0x00, 0x07, 0x00, 0x00, 0x00, 0x02, 0x00, 0x00},
+	).(*MsgMhfStateCampaign)
+
+	if pkt.AckHandle != 7 || pkt.CampaignID != 2 {
+		t.Errorf("got ack handle %d and campaign %d, want 7 and 2", pkt.AckHandle, pkt.CampaignID)
+	}
+}
+
+func TestParseUpdateGuildItem(t *testing.T) {
+	payload := []byte{
+		0x00, 0x00, 0x00, 0x08, // Ack handle
+		0x00, 0x00, 0x00, 0x03, // Guild
+		0x00, 0x02, // Changes
+		0x00, 0x00, // Unk
+		0x00, 0x00, 0x00, 0x01, 0x00, 0x10, 0x00, 0x05, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x20, 0x27, 0x0F, 0x00, 0x00, 0x00, 0x00,
+	}
+
+	pkt := parsePacketGroup(t, network.MSG_MHF_UPDATE_GUILD_ITEM, payload).(*MsgMhfUpdateGuildItem)
+
+	if pkt.AckHandle != 8 || pkt.GuildID != 3 {
+		t.Errorf("got ack handle %d and guild %d, want 8 and 3", pkt.AckHandle, pkt.GuildID)
+	}
+
+	want := []WarehouseItem{
+		{WarehouseID: 1, ItemID: 0x10, Quantity: 5},
+		{WarehouseID: 0, ItemID: 0x20, Quantity: 9999},
+	}
+
+	if len(pkt.UpdatedItems) != len(want) {
+		t.Fatalf("got %d items, want %d", len(pkt.UpdatedItems), len(want))
+	}
+
+	for i, item := range pkt.UpdatedItems {
+		if *item != want[i] {
+			t.Errorf("item %d: got %+v, want %+v", i, *item, want[i])
+		}
+	}
+}
+
+func TestParseAddGuildMissionCount(t *testing.T) {
+	pkt := parsePacketGroup(
+		t,
+		network.MSG_MHF_ADD_GUILD_MISSION_COUNT,
+		[]byte{0x00, 0x00, 0x00, 0x09, 0x00, 0x00, 0x00, 0x0C, 0xFF, 0xFF, 0xFF, 0xFF},
+	).(*MsgMhfAddGuildMissionCount)
+
+	if pkt.AckHandle != 9 || pkt.MissionID != 12 || pkt.Count != 0xFFFFFFFF {
+		t.Errorf("got ack handle %d, mission %d, count %d, want 9, 12, %d", pkt.AckHandle, pkt.MissionID, pkt.Count, uint32(0xFFFFFFFF))
+	}
+}
+
+func TestParseSetGuildMissionTarget(t *testing.T) {
+	pkt := parsePacketGroup(
+		t,
+		network.MSG_MHF_SET_GUILD_MISSION_TARGET,
+		[]byte{0x00, 0x00, 0x00, 0x09, 0x00, 0x00, 0x00, 0x0C},
+	).(*MsgMhfSetGuildMissionTarget)
+
+	if pkt.AckHandle != 9 || pkt.MissionID != 12 {
+		t.Errorf("got ack handle %d and mission %d, want 9 and 12", pkt.AckHandle, pkt.MissionID)
+	}
+}
+
+func TestParseOperateGuildTresureReport(t *testing.T) {
+	pkt := parsePacketGroup(
+		t,
+		network.MSG_MHF_OPERATE_GUILD_TRESURE_REPORT,
+		[]byte{0x00, 0x00, 0x00, 0x0A, 0x00, 0x00, 0x00, 0x03, 0x00, 0x02},
+	).(*MsgMhfOperateGuildTresureReport)
+
+	if pkt.AckHandle != 10 || pkt.HuntID != 3 || pkt.State != 2 {
+		t.Errorf("got ack handle %d, hunt %d, state %d, want 10, 3, 2", pkt.AckHandle, pkt.HuntID, pkt.State)
+	}
+}
+
+func TestParseRegistGuildTresure(t *testing.T) {
+	pkt := parsePacketGroup(
+		t,
+		network.MSG_MHF_REGIST_GUILD_TRESURE,
+		[]byte{0x00, 0x00, 0x00, 0x0A, 0x00, 0x03, 0x01, 0x02, 0x03},
+	).(*MsgMhfRegistGuildTresure)
+
+	if pkt.AckHandle != 10 || !bytes.Equal(pkt.Data, []byte{0x01, 0x02, 0x03}) {
+		t.Errorf("got ack handle %d and data % X, want 10 and 01 02 03", pkt.AckHandle, pkt.Data)
+	}
+}
+
+func TestParseRegistGuildCooking(t *testing.T) {
+	pkt := parsePacketGroup(
+		t,
+		network.MSG_MHF_REGIST_GUILD_COOKING,
+		[]byte{0x00, 0x00, 0x00, 0x0B, 0x00, 0x00, 0x00, 0x04, 0x00, 0x11, 0x02},
+	).(*MsgMhfRegistGuildCooking)
+
+	if pkt.AckHandle != 11 || pkt.OverwriteID != 4 || pkt.MealID != 0x11 || pkt.Success != 2 {
+		t.Errorf(
+			"got ack handle %d, overwrite %d, meal %d, success %d, want 11, 4, 17, 2",
+			pkt.AckHandle, pkt.OverwriteID, pkt.MealID, pkt.Success,
+		)
+	}
+}
+
+func TestParseChargeGuildAdventure(t *testing.T) {
+	pkt := parsePacketGroup(
+		t,
+		network.MSG_MHF_CHARGE_GUILD_ADVENTURE,
+		[]byte{0x00, 0x00, 0x00, 0x0B, 0x00, 0x00, 0x00, 0x05, 0x00, 0x00, 0x01, 0x00},
+	).(*MsgMhfChargeGuildAdventure)
+
+	if pkt.AckHandle != 11 || pkt.AdventureID != 5 || pkt.Amount != 0x100 {
+		t.Errorf("got ack handle %d, adventure %d, amount %d, want 11, 5, 256", pkt.AckHandle, pkt.AdventureID, pkt.Amount)
+	}
+}
+
+func TestParseRegistGuildAdventure(t *testing.T) {
+	pkt := parsePacketGroup(
+		t,
+		network.MSG_MHF_REGIST_GUILD_ADVENTURE,
+		[]byte{0x00, 0x00, 0x00, 0x0B, 0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00, 0x09},
+	).(*MsgMhfRegistGuildAdventure)
+
+	if pkt.AckHandle != 11 || pkt.Destination != 2 || pkt.CharID != 9 {
+		t.Errorf("got ack handle %d, destination %d, character %d, want 11, 2, 9", pkt.AckHandle, pkt.Destination, pkt.CharID)
+	}
+}
+
+func TestParseEnumerateGuild(t *testing.T) {
+	payload := []byte{
+		0x00, 0x00, 0x00, 0x0C, // Ack handle
+		0x01,                   // Type
+		0x02,                   // Page
+		0x01,                   // Sorting
+		0x00,                   // Unk
+		0x00, 0x00, 0x00, 0x2A, // Data1
+		0x00, 0x00, // Unk
+		0x04, // Data2 length
+		0x00, // Unk
+		'a', 'b', 'c', 0x00,
+	}
+
+	pkt := parsePacketGroup(t, network.MSG_MHF_ENUMERATE_GUILD, payload).(*MsgMhfEnumerateGuild)
+
+	if pkt.AckHandle != 12 || pkt.Type != 1 || pkt.Page != 2 || !pkt.Sorting {
+		t.Errorf("got ack handle %d, type %d, page %d, sorting %v, want 12, 1, 2, true", pkt.AckHandle, pkt.Type, pkt.Page, pkt.Sorting)
+	}
+
+	if !bytes.Equal(pkt.Data1, []byte{0x00, 0x00, 0x00, 0x2A}) || string(pkt.Data2) != "abc\x00" {
+		t.Errorf("got data % X and %q, want 00 00 00 2A and \"abc\\x00\"", pkt.Data1, pkt.Data2)
+	}
+}
+
+func TestParseAddGuildWeeklyBonusExceptionalUser(t *testing.T) {
+	pkt := parsePacketGroup(
+		t,
+		network.MSG_MHF_ADD_GUILD_WEEKLY_BONUS_EXCEPTIONAL_USER,
+		[]byte{0x00, 0x00, 0x00, 0x0D, 0x03},
+	).(*MsgMhfAddGuildWeeklyBonusExceptionalUser)
+
+	if pkt.AckHandle != 13 || pkt.NumUsers != 3 {
+		t.Errorf("got ack handle %d, users %d, want 13, 3", pkt.AckHandle, pkt.NumUsers)
+	}
+}
+
+func TestParseGuildHuntdata(t *testing.T) {
+	pkt := parsePacketGroup(
+		t,
+		network.MSG_MHF_GUILD_HUNTDATA,
+		[]byte{0x00, 0x00, 0x00, 0x0E, 0x02},
+	).(*MsgMhfGuildHuntdata)
+
+	if pkt.AckHandle != 14 || pkt.Operation != GUILD_HUNTDATA_CHECK {
+		t.Errorf("got ack handle %d, operation %d, want 14, %d", pkt.AckHandle, pkt.Operation, GUILD_HUNTDATA_CHECK)
+	}
+}
+
+func TestParseOperateGuild(t *testing.T) {
+	payload := []byte{
+		0x00, 0x00, 0x00, 0x0F, // Ack handle
+		0x00, 0x00, 0x00, 0x05, // Guild ID
+		OPERATE_GUILD_ACTION_DONATE,
+		0x00, 0x00, 0x01, 0x00,
+	}
+
+	pkt := parsePacket(t, network.MSG_MHF_OPERATE_GUILD, payload).(*MsgMhfOperateGuild)
+
+	if pkt.AckHandle != 15 || pkt.GuildID != 5 || pkt.Action != OPERATE_GUILD_ACTION_DONATE {
+		t.Errorf("got ack handle %d, guild %d, action %d, want 15, 5, %d", pkt.AckHandle, pkt.GuildID, pkt.Action, OPERATE_GUILD_ACTION_DONATE)
+	}
+
+	if !bytes.Equal(pkt.UnkData, []byte{0x00, 0x00, 0x01, 0x00}) {
+		t.Errorf("got data % X, want 00 00 01 00", pkt.UnkData)
+	}
+}
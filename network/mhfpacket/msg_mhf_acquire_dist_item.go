@@ -9,8 +9,8 @@ import (
 type MsgMhfAcquireDistItem struct {
 	AckHandle uint32
 	// Valid field size(s), not sure about the types.
-	Unk0 uint8
-	Unk1 uint32
+	Unk0           uint8
+	DistributionID uint32
 }
 
 // Opcode returns the ID associated with this packet type.
@@ -22,7 +22,7 @@ func (m *MsgMhfAcquireDistItem) Opcode() network.PacketID {
 func (m *MsgMhfAcquireDistItem) Parse(bf *byteframe.ByteFrame) error {
 	m.AckHandle = bf.ReadUint32()
 	m.Unk0 = bf.ReadUint8()
-	m.Unk1 = bf.ReadUint32()
+	m.DistributionID = bf.ReadUint32()
 	return nil
 }
 
@@ -6,7 +6,10 @@ import (
 )
 
 // MsgMhfAcquireGuildAdventure represents the MSG_MHF_ACQUIRE_GUILD_ADVENTURE
-type MsgMhfAcquireGuildAdventure struct{}
+type MsgMhfAcquireGuildAdventure struct {
+	AckHandle   uint32
+	AdventureID uint32
+}
 
 // Opcode returns the ID associated with this packet type.
 func (m *MsgMhfAcquireGuildAdventure) Opcode() network.PacketID {
@@ -15,7 +18,10 @@ func (m *MsgMhfAcquireGuildAdventure) Opcode() network.PacketID {
 
 // Parse parses the packet from binary
 func (m *MsgMhfAcquireGuildAdventure) Parse(bf *byteframe.ByteFrame) error {
-	panic("Not implemented")
+	m.AckHandle = bf.ReadUint32()
+	m.AdventureID = bf.ReadUint32()
+
+	return nil
 }
 
 // Build builds a binary packet from the current data.
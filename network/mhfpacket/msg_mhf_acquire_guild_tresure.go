@@ -6,7 +6,11 @@ import (
 )
 
 // MsgMhfAcquireGuildTresure represents the MSG_MHF_ACQUIRE_GUILD_TRESURE
-type MsgMhfAcquireGuildTresure struct{}
+type MsgMhfAcquireGuildTresure struct {
+	AckHandle uint32
+	HuntID    uint32
+	Unk0      uint8
+}
 
 // Opcode returns the ID associated with this packet type.
 func (m *MsgMhfAcquireGuildTresure) Opcode() network.PacketID {
@@ -15,7 +19,11 @@ func (m *MsgMhfAcquireGuildTresure) Opcode() network.PacketID {
 
 // Parse parses the packet from binary
 func (m *MsgMhfAcquireGuildTresure) Parse(bf *byteframe.ByteFrame) error {
-	panic("Not implemented")
+	m.AckHandle = bf.ReadUint32()
+	m.HuntID = bf.ReadUint32()
+	m.Unk0 = bf.ReadUint8()
+
+	return nil
 }
 
 // Build builds a binary packet from the current data.
@@ -6,7 +6,11 @@ import (
 )
 
 // MsgMhfAcquireTitle represents the MSG_MHF_ACQUIRE_TITLE
-type MsgMhfAcquireTitle struct{}
+type MsgMhfAcquireTitle struct {
+	AckHandle uint32
+	Unk0      uint16 // Hardcoded 0 in the binary
+	TitleIDs  []uint16
+}
 
 // Opcode returns the ID associated with this packet type.
 func (m *MsgMhfAcquireTitle) Opcode() network.PacketID {
@@ -15,7 +19,13 @@ func (m *MsgMhfAcquireTitle) Opcode() network.PacketID {
 
 // Parse parses the packet from binary
 func (m *MsgMhfAcquireTitle) Parse(bf *byteframe.ByteFrame) error {
-	panic("Not implemented")
+	m.AckHandle = bf.ReadUint32()
+	count := bf.ReadUint16()
+	m.Unk0 = bf.ReadUint16()
+	for i := 0; i < int(count); i++ {
+		m.TitleIDs = append(m.TitleIDs, bf.ReadUint16())
+	}
+	return nil
 }
 
 // Build builds a binary packet from the current data.
@@ -7,9 +7,9 @@ import (
 
 // MsgMhfAddAchievement represents the MSG_MHF_ADD_ACHIEVEMENT
 type MsgMhfAddAchievement struct {
-	Unk0 uint8
-	Unk1 uint16
-	Unk2 uint16
+	AchievementID uint8
+	Unk1          uint16
+	Unk2          uint16
 }
 
 // Opcode returns the ID associated with this packet type.
@@ -19,7 +19,7 @@ func (m *MsgMhfAddAchievement) Opcode() network.PacketID {
 
 // Parse parses the packet from binary
 func (m *MsgMhfAddAchievement) Parse(bf *byteframe.ByteFrame) error {
-	m.Unk0 = bf.ReadUint8()
+	m.AchievementID = bf.ReadUint8()
 	m.Unk1 = bf.ReadUint16()
 	m.Unk2 = bf.ReadUint16()
 	// doesn't expect a response
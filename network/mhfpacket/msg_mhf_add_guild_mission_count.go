@@ -6,7 +6,11 @@ import (
 )
 
 // MsgMhfAddGuildMissionCount represents the MSG_MHF_ADD_GUILD_MISSION_COUNT
-type MsgMhfAddGuildMissionCount struct{}
+type MsgMhfAddGuildMissionCount struct {
+	AckHandle uint32
+	MissionID uint32
+	Count     uint32
+}
 
 // Opcode returns the ID associated with this packet type.
 func (m *MsgMhfAddGuildMissionCount) Opcode() network.PacketID {
@@ -15,7 +19,11 @@ func (m *MsgMhfAddGuildMissionCount) Opcode() network.PacketID {
 
 // Parse parses the packet from binary
 func (m *MsgMhfAddGuildMissionCount) Parse(bf *byteframe.ByteFrame) error {
-	panic("Not implemented")
+	m.AckHandle = bf.ReadUint32()
+	m.MissionID = bf.ReadUint32()
+	m.Count = bf.ReadUint32()
+
+	return nil
 }
 
 // Build builds a binary packet from the current data.
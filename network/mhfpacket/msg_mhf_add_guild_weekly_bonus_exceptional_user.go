@@ -6,7 +6,10 @@ import (
 )
 
 // MsgMhfAddGuildWeeklyBonusExceptionalUser represents the MSG_MHF_ADD_GUILD_WEEKLY_BONUS_EXCEPTIONAL_USER
-type MsgMhfAddGuildWeeklyBonusExceptionalUser struct{}
+type MsgMhfAddGuildWeeklyBonusExceptionalUser struct {
+	AckHandle uint32
+	NumUsers  uint8
+}
 
 // Opcode returns the ID associated with this packet type.
 func (m *MsgMhfAddGuildWeeklyBonusExceptionalUser) Opcode() network.PacketID {
@@ -15,7 +18,9 @@ func (m *MsgMhfAddGuildWeeklyBonusExceptionalUser) Opcode() network.PacketID {
 
 // Parse parses the packet from binary
 func (m *MsgMhfAddGuildWeeklyBonusExceptionalUser) Parse(bf *byteframe.ByteFrame) error {
-	panic("Not implemented")
+	m.AckHandle = bf.ReadUint32()
+	m.NumUsers = bf.ReadUint8()
+	return nil
 }
 
 // Build builds a binary packet from the current data.
@@ -6,7 +6,12 @@ import (
 )
 
 // MsgMhfApplyCampaign represents the MSG_MHF_APPLY_CAMPAIGN
-type MsgMhfApplyCampaign struct{}
+type MsgMhfApplyCampaign struct {
+	AckHandle  uint32
+	CampaignID uint32
+	Unk0       uint16
+	Code       []byte // Null terminated Shift-JIS entry code
+}
 
 // Opcode returns the ID associated with this packet type.
 func (m *MsgMhfApplyCampaign) Opcode() network.PacketID {
@@ -15,7 +20,11 @@ func (m *MsgMhfApplyCampaign) Opcode() network.PacketID {
 
 // Parse parses the packet from binary
 func (m *MsgMhfApplyCampaign) Parse(bf *byteframe.ByteFrame) error {
-	panic("Not implemented")
+	m.AckHandle = bf.ReadUint32()
+	m.CampaignID = bf.ReadUint32()
+	m.Unk0 = bf.ReadUint16()
+	m.Code = bf.ReadBytes(16)
+	return nil
 }
 
 // Build builds a binary packet from the current data.
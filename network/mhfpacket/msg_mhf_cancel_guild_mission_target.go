@@ -6,7 +6,10 @@ import (
 )
 
 // MsgMhfCancelGuildMissionTarget represents the MSG_MHF_CANCEL_GUILD_MISSION_TARGET
-type MsgMhfCancelGuildMissionTarget struct{}
+type MsgMhfCancelGuildMissionTarget struct {
+	AckHandle uint32
+	MissionID uint32
+}
 
 // Opcode returns the ID associated with this packet type.
 func (m *MsgMhfCancelGuildMissionTarget) Opcode() network.PacketID {
@@ -15,7 +18,10 @@ func (m *MsgMhfCancelGuildMissionTarget) Opcode() network.PacketID {
 
 // Parse parses the packet from binary
 func (m *MsgMhfCancelGuildMissionTarget) Parse(bf *byteframe.ByteFrame) error {
-	panic("Not implemented")
+	m.AckHandle = bf.ReadUint32()
+	m.MissionID = bf.ReadUint32()
+
+	return nil
 }
 
 // Build builds a binary packet from the current data.
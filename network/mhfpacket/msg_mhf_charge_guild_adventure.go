@@ -6,7 +6,11 @@ import (
 )
 
 // MsgMhfChargeGuildAdventure represents the MSG_MHF_CHARGE_GUILD_ADVENTURE
-type MsgMhfChargeGuildAdventure struct{}
+type MsgMhfChargeGuildAdventure struct {
+	AckHandle   uint32
+	AdventureID uint32
+	Amount      uint32
+}
 
 // Opcode returns the ID associated with this packet type.
 func (m *MsgMhfChargeGuildAdventure) Opcode() network.PacketID {
@@ -15,7 +19,11 @@ func (m *MsgMhfChargeGuildAdventure) Opcode() network.PacketID {
 
 // Parse parses the packet from binary
 func (m *MsgMhfChargeGuildAdventure) Parse(bf *byteframe.ByteFrame) error {
-	panic("Not implemented")
+	m.AckHandle = bf.ReadUint32()
+	m.AdventureID = bf.ReadUint32()
+	m.Amount = bf.ReadUint32()
+
+	return nil
 }
 
 // Build builds a binary packet from the current data.
@@ -6,7 +6,11 @@ import (
 )
 
 // MsgMhfCreateJoint represents the MSG_MHF_CREATE_JOINT
-type MsgMhfCreateJoint struct{}
+type MsgMhfCreateJoint struct {
+	AckHandle uint32
+	GuildID   uint32
+	Name      string
+}
 
 // Opcode returns the ID associated with this packet type.
 func (m *MsgMhfCreateJoint) Opcode() network.PacketID {
@@ -15,7 +19,11 @@ func (m *MsgMhfCreateJoint) Opcode() network.PacketID {
 
 // Parse parses the packet from binary
 func (m *MsgMhfCreateJoint) Parse(bf *byteframe.ByteFrame) error {
-	panic("Not implemented")
+	m.AckHandle = bf.ReadUint32()
+	m.GuildID = bf.ReadUint32()
+	m.Name = string(bf.ReadBytes(uint(bf.ReadUint16())))
+
+	return nil
 }
 
 // Build builds a binary packet from the current data.
@@ -7,7 +7,7 @@ import (
 
 // MsgMhfDisplayedAchievement represents the MSG_MHF_DISPLAYED_ACHIEVEMENT
 type MsgMhfDisplayedAchievement struct{
-	Unk0      uint8
+	AchievementID uint8
 }
 
 // Opcode returns the ID associated with this packet type.
@@ -17,7 +17,7 @@ func (m *MsgMhfDisplayedAchievement) Opcode() network.PacketID {
 
 // Parse parses the packet from binary
 func (m *MsgMhfDisplayedAchievement) Parse(bf *byteframe.ByteFrame) error {
-	m.Unk0 = bf.ReadUint8()
+	m.AchievementID = bf.ReadUint8()
 	return nil
 }
 
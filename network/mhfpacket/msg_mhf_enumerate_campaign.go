@@ -6,7 +6,11 @@ import (
 )
 
 // MsgMhfEnumerateCampaign represents the MSG_MHF_ENUMERATE_CAMPAIGN
-type MsgMhfEnumerateCampaign struct{}
+type MsgMhfEnumerateCampaign struct {
+	AckHandle uint32
+	Unk0      uint16
+	Unk1      uint16
+}
 
 // Opcode returns the ID associated with this packet type.
 func (m *MsgMhfEnumerateCampaign) Opcode() network.PacketID {
@@ -15,7 +19,10 @@ func (m *MsgMhfEnumerateCampaign) Opcode() network.PacketID {
 
 // Parse parses the packet from binary
 func (m *MsgMhfEnumerateCampaign) Parse(bf *byteframe.ByteFrame) error {
-	panic("Not implemented")
+	m.AckHandle = bf.ReadUint32()
+	m.Unk0 = bf.ReadUint16()
+	m.Unk1 = bf.ReadUint16()
+	return nil
 }
 
 // Build builds a binary packet from the current data.
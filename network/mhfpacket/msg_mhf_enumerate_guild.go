@@ -10,21 +10,26 @@ type EnumerateGuildType uint8
 const (
 	_ = iota
 	ENUMERATE_GUILD_TYPE_NAME
-	//Numbers correspond to order in guild search menu
-	ENUMERATE_GUILD_TYPE_6
+	ENUMERATE_GUILD_TYPE_LEADER_NAME
 	ENUMERATE_GUILD_TYPE_LEADER_ID
-	ENUMERATE_GUILD_TYPE_3
-	ENUMERATE_GUILD_TYPE_2
-	ENUMERATE_GUILD_TYPE_7
-	ENUMERATE_GUILD_TYPE_8
+	ENUMERATE_GUILD_TYPE_ORDER_MEMBERS
+	ENUMERATE_GUILD_TYPE_ORDER_REGISTRATION
+	ENUMERATE_GUILD_TYPE_ORDER_RANK
+	ENUMERATE_GUILD_TYPE_MOTTO
 	ENUMERATE_GUILD_TYPE_NEW
 )
 
 // MsgMhfEnumerateGuild represents the MSG_MHF_ENUMERATE_GUILD
 type MsgMhfEnumerateGuild struct {
-	AckHandle      uint32
-	Type           uint8
-	RawDataPayload []byte
+	AckHandle uint32
+	Type      uint8
+	Page      uint8
+	// Reverses the default order of the results.
+	Sorting bool
+	// Search value for types that take a number, e.g. the leader's ID or the mottos.
+	Data1 []byte
+	// Search term for types that take text, Shift-JIS encoded.
+	Data2 []byte
 }
 
 // Opcode returns the ID associated with this packet type.
@@ -36,7 +41,14 @@ func (m *MsgMhfEnumerateGuild) Opcode() network.PacketID {
 func (m *MsgMhfEnumerateGuild) Parse(bf *byteframe.ByteFrame) error {
 	m.AckHandle = bf.ReadUint32()
 	m.Type = bf.ReadUint8()
-	m.RawDataPayload = bf.DataFromCurrent()
+	m.Page = bf.ReadUint8()
+	m.Sorting = bf.ReadBool()
+	_ = bf.ReadUint8()
+	m.Data1 = bf.ReadBytes(4)
+	_ = bf.ReadUint16()
+	dataLength := bf.ReadUint8()
+	_ = bf.ReadUint8()
+	m.Data2 = bf.ReadBytes(uint(dataLength))
 
 	return nil
 }
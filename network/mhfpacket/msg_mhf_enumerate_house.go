@@ -6,7 +6,13 @@ import (
 )
 
 // MsgMhfEnumerateHouse represents the MSG_MHF_ENUMERATE_HOUSE
-type MsgMhfEnumerateHouse struct{}
+type MsgMhfEnumerateHouse struct {
+	AckHandle uint32
+	CharID    uint32
+	Method    uint8 // 1 = friends, 2 = guild, 3 = name search, 4 = character ID, 5 = recent visitors
+	Unk0      uint16
+	Name      string // Shift-JIS, only sent when searching by name
+}
 
 // Opcode returns the ID associated with this packet type.
 func (m *MsgMhfEnumerateHouse) Opcode() network.PacketID {
@@ -15,7 +21,15 @@ func (m *MsgMhfEnumerateHouse) Opcode() network.PacketID {
 
 // Parse parses the packet from binary
 func (m *MsgMhfEnumerateHouse) Parse(bf *byteframe.ByteFrame) error {
-	panic("Not implemented")
+	m.AckHandle = bf.ReadUint32()
+	m.CharID = bf.ReadUint32()
+	m.Method = bf.ReadUint8()
+	m.Unk0 = bf.ReadUint16()
+	nameLength := bf.ReadUint8()
+	if nameLength > 0 {
+		m.Name = string(bf.ReadNullTerminatedBytes())
+	}
+	return nil
 }
 
 // Build builds a binary packet from the current data.
@@ -6,7 +6,10 @@ import (
 )
 
 // MsgMhfEnumerateTitle represents the MSG_MHF_ENUMERATE_TITLE
-type MsgMhfEnumerateTitle struct{}
+type MsgMhfEnumerateTitle struct {
+	AckHandle uint32
+	CharID    uint32
+}
 
 // Opcode returns the ID associated with this packet type.
 func (m *MsgMhfEnumerateTitle) Opcode() network.PacketID {
@@ -15,7 +18,9 @@ func (m *MsgMhfEnumerateTitle) Opcode() network.PacketID {
 
 // Parse parses the packet from binary
 func (m *MsgMhfEnumerateTitle) Parse(bf *byteframe.ByteFrame) error {
-	panic("Not implemented")
+	m.AckHandle = bf.ReadUint32()
+	m.CharID = bf.ReadUint32()
+	return nil
 }
 
 // Build builds a binary packet from the current data.
@@ -6,7 +6,12 @@ import (
 )
 
 // MsgMhfEnumerateWarehouse represents the MSG_MHF_ENUMERATE_WAREHOUSE
-type MsgMhfEnumerateWarehouse struct{}
+type MsgMhfEnumerateWarehouse struct {
+	AckHandle uint32
+	BoxType   uint8
+	BoxIndex  uint8
+	Unk0      uint16
+}
 
 // Opcode returns the ID associated with this packet type.
 func (m *MsgMhfEnumerateWarehouse) Opcode() network.PacketID {
@@ -15,7 +20,11 @@ func (m *MsgMhfEnumerateWarehouse) Opcode() network.PacketID {
 
 // Parse parses the packet from binary
 func (m *MsgMhfEnumerateWarehouse) Parse(bf *byteframe.ByteFrame) error {
-	panic("Not implemented")
+	m.AckHandle = bf.ReadUint32()
+	m.BoxType = bf.ReadUint8()
+	m.BoxIndex = bf.ReadUint8()
+	m.Unk0 = bf.ReadUint16()
+	return nil
 }
 
 // Build builds a binary packet from the current data.
@@ -5,10 +5,18 @@ import (
 	"github.com/Andoryuuta/byteframe"
 )
 
+type GuildHuntdataOperation uint8
+
+const (
+	GUILD_HUNTDATA_ACQUIRE   = 0x00
+	GUILD_HUNTDATA_ENUMERATE = 0x01
+	GUILD_HUNTDATA_CHECK     = 0x02
+)
+
 // MsgMhfGuildHuntdata represents the MSG_MHF_GUILD_HUNTDATA
-type MsgMhfGuildHuntdata struct{
-	AckHandle      uint32
-	Unk0           uint8
+type MsgMhfGuildHuntdata struct {
+	AckHandle uint32
+	Operation GuildHuntdataOperation
 }
 
 // Opcode returns the ID associated with this packet type.
@@ -19,7 +27,7 @@ func (m *MsgMhfGuildHuntdata) Opcode() network.PacketID {
 // Parse parses the packet from binary
 func (m *MsgMhfGuildHuntdata) Parse(bf *byteframe.ByteFrame) error {
 	m.AckHandle = bf.ReadUint32()
-	m.Unk0 = bf.ReadUint8()
+	m.Operation = GuildHuntdataOperation(bf.ReadUint8())
 	return nil
 }
 
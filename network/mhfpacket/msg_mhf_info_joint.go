@@ -6,7 +6,10 @@ import (
 )
 
 // MsgMhfInfoJoint represents the MSG_MHF_INFO_JOINT
-type MsgMhfInfoJoint struct{}
+type MsgMhfInfoJoint struct {
+	AckHandle  uint32
+	AllianceID uint32
+}
 
 // Opcode returns the ID associated with this packet type.
 func (m *MsgMhfInfoJoint) Opcode() network.PacketID {
@@ -15,7 +18,10 @@ func (m *MsgMhfInfoJoint) Opcode() network.PacketID {
 
 // Parse parses the packet from binary
 func (m *MsgMhfInfoJoint) Parse(bf *byteframe.ByteFrame) error {
-	panic("Not implemented")
+	m.AckHandle = bf.ReadUint32()
+	m.AllianceID = bf.ReadUint32()
+
+	return nil
 }
 
 // Build builds a binary packet from the current data.
@@ -8,12 +8,12 @@ import (
 // MsgMhfLoadHouse represents the MSG_MHF_LOAD_HOUSE
 type MsgMhfLoadHouse struct {
 	AckHandle      uint32
-	Unk0           uint32
-	Unk1           uint8
-	Unk2           uint8
+	CharID         uint32
+	Destination    uint8 // 3 when visiting another character's house
+	CheckPass      bool
 	Unk3           uint16 // Hardcoded 0 in binary
-	DataSize       uint8
-	RawDataPayload []byte
+	PasswordLength uint8
+	Password       []byte
 }
 
 // Opcode returns the ID associated with this packet type.
@@ -24,12 +24,12 @@ func (m *MsgMhfLoadHouse) Opcode() network.PacketID {
 // Parse parses the packet from binary
 func (m *MsgMhfLoadHouse) Parse(bf *byteframe.ByteFrame) error {
 	m.AckHandle = bf.ReadUint32()
-	m.Unk0 = bf.ReadUint32()
-	m.Unk1 = bf.ReadUint8()
-	m.Unk2 = bf.ReadUint8()
+	m.CharID = bf.ReadUint32()
+	m.Destination = bf.ReadUint8()
+	m.CheckPass = bf.ReadBool()
 	m.Unk3 = bf.ReadUint16()
-	m.DataSize = bf.ReadUint8()
-	m.RawDataPayload = bf.ReadBytes(uint(m.DataSize))
+	m.PasswordLength = bf.ReadUint8()
+	m.Password = bf.ReadBytes(uint(m.PasswordLength))
 	return nil
 }
 
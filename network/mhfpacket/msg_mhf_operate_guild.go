@@ -11,11 +11,21 @@ const (
 	OPERATE_GUILD_ACTION_DISBAND             = 0x01
 	OPERATE_GUILD_ACTION_APPLY               = 0x02
 	OPERATE_GUILD_ACTION_LEAVE               = 0x03
+	OPERATE_GUILD_ACTION_RESIGN              = 0x04
+	OPERATE_GUILD_SET_APPLICATION_DENY       = 0x05
+	OPERATE_GUILD_SET_APPLICATION_ALLOW      = 0x06
 	OPERATE_GUILD_SET_AVOID_LEADERSHIP_TRUE  = 0x07
 	OPERATE_GUILD_SET_AVOID_LEADERSHIP_FALSE = 0x08
 	OPERATE_GUILD_ACTION_UPDATE_COMMENT      = 0x09
 	OPERATE_GUILD_ACTION_DONATE              = 0x0a
 	OPERATE_GUILD_ACTION_UPDATE_MOTTO        = 0x0b
+	OPERATE_GUILD_ACTION_RENAME_PUGI_1       = 0x0c
+	OPERATE_GUILD_ACTION_RENAME_PUGI_2       = 0x0d
+	OPERATE_GUILD_ACTION_RENAME_PUGI_3       = 0x0e
+	OPERATE_GUILD_ACTION_CHANGE_PUGI_1       = 0x0f
+	OPERATE_GUILD_ACTION_CHANGE_PUGI_2       = 0x10
+	OPERATE_GUILD_ACTION_CHANGE_PUGI_3       = 0x11
+	OPERATE_GUILD_ACTION_UNLOCK_PUGI_OUTFIT  = 0x12
 )
 
 // MsgMhfOperateGuild represents the MSG_MHF_OPERATE_GUILD
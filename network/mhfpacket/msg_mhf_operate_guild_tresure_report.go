@@ -5,8 +5,18 @@ import (
 	"github.com/Andoryuuta/byteframe"
 )
 
+const (
+	GUILD_TRESURE_REPORT_CANCEL   = 0x00
+	GUILD_TRESURE_REPORT_COMPLETE = 0x01
+	GUILD_TRESURE_REPORT_CLAIM    = 0x02
+)
+
 // MsgMhfOperateGuildTresureReport represents the MSG_MHF_OPERATE_GUILD_TRESURE_REPORT
-type MsgMhfOperateGuildTresureReport struct{}
+type MsgMhfOperateGuildTresureReport struct {
+	AckHandle uint32
+	HuntID    uint32
+	State     uint16
+}
 
 // Opcode returns the ID associated with this packet type.
 func (m *MsgMhfOperateGuildTresureReport) Opcode() network.PacketID {
@@ -15,7 +25,11 @@ func (m *MsgMhfOperateGuildTresureReport) Opcode() network.PacketID {
 
 // Parse parses the packet from binary
 func (m *MsgMhfOperateGuildTresureReport) Parse(bf *byteframe.ByteFrame) error {
-	panic("Not implemented")
+	m.AckHandle = bf.ReadUint32()
+	m.HuntID = bf.ReadUint32()
+	m.State = bf.ReadUint16()
+
+	return nil
 }
 
 // Build builds a binary packet from the current data.
@@ -5,8 +5,25 @@ import (
 	"github.com/Andoryuuta/byteframe"
 )
 
+type OperateJointAction uint8
+
+const (
+	OPERATE_JOINT_DISBAND OperateJointAction = 0x01
+	OPERATE_JOINT_INVITE  OperateJointAction = 0x02
+	OPERATE_JOINT_LEAVE   OperateJointAction = 0x03
+	OPERATE_JOINT_ACCEPT  OperateJointAction = 0x04
+	OPERATE_JOINT_DECLINE OperateJointAction = 0x05
+	OPERATE_JOINT_KICK    OperateJointAction = 0x09
+)
+
 // MsgMhfOperateJoint represents the MSG_MHF_OPERATE_JOINT
-type MsgMhfOperateJoint struct{}
+type MsgMhfOperateJoint struct {
+	AckHandle  uint32
+	AllianceID uint32
+	GuildID    uint32
+	Action     OperateJointAction
+	UnkData    []byte
+}
 
 // Opcode returns the ID associated with this packet type.
 func (m *MsgMhfOperateJoint) Opcode() network.PacketID {
@@ -15,7 +32,13 @@ func (m *MsgMhfOperateJoint) Opcode() network.PacketID {
 
 // Parse parses the packet from binary
 func (m *MsgMhfOperateJoint) Parse(bf *byteframe.ByteFrame) error {
-	panic("Not implemented")
+	m.AckHandle = bf.ReadUint32()
+	m.AllianceID = bf.ReadUint32()
+	m.GuildID = bf.ReadUint32()
+	m.Action = OperateJointAction(bf.ReadUint8())
+	m.UnkData = bf.DataFromCurrent()
+
+	return nil
 }
 
 // Build builds a binary packet from the current data.
@@ -6,7 +6,14 @@ import (
 )
 
 // MsgMhfOperateWarehouse represents the MSG_MHF_OPERATE_WAREHOUSE
-type MsgMhfOperateWarehouse struct{}
+type MsgMhfOperateWarehouse struct {
+	AckHandle uint32
+	Operation uint8
+	BoxType   uint8
+	BoxIndex  uint8
+	Unk0      uint16
+	Name      string // Shift-JIS, only sent when renaming
+}
 
 // Opcode returns the ID associated with this packet type.
 func (m *MsgMhfOperateWarehouse) Opcode() network.PacketID {
@@ -15,7 +22,16 @@ func (m *MsgMhfOperateWarehouse) Opcode() network.PacketID {
 
 // Parse parses the packet from binary
 func (m *MsgMhfOperateWarehouse) Parse(bf *byteframe.ByteFrame) error {
-	panic("Not implemented")
+	m.AckHandle = bf.ReadUint32()
+	m.Operation = bf.ReadUint8()
+	m.BoxType = bf.ReadUint8()
+	m.BoxIndex = bf.ReadUint8()
+	nameLength := bf.ReadUint8()
+	m.Unk0 = bf.ReadUint16()
+	if nameLength > 0 {
+		m.Name = string(bf.ReadNullTerminatedBytes())
+	}
+	return nil
 }
 
 // Build builds a binary packet from the current data.
@@ -6,7 +6,12 @@ import (
 )
 
 // MsgMhfOprMember represents the MSG_MHF_OPR_MEMBER
-type MsgMhfOprMember struct{}
+type MsgMhfOprMember struct {
+	AckHandle uint32
+	Blacklist bool // Whether the blacklist is operated on instead of the friends list
+	Operation bool // true = remove, false = add
+	CharIDs   []uint32
+}
 
 // Opcode returns the ID associated with this packet type.
 func (m *MsgMhfOprMember) Opcode() network.PacketID {
@@ -15,7 +20,17 @@ func (m *MsgMhfOprMember) Opcode() network.PacketID {
 
 // Parse parses the packet from binary
 func (m *MsgMhfOprMember) Parse(bf *byteframe.ByteFrame) error {
-	panic("Not implemented")
+	m.AckHandle = bf.ReadUint32()
+	m.Blacklist = bf.ReadBool()
+	m.Operation = bf.ReadBool()
+	_ = bf.ReadUint8() // Unk
+	count := bf.ReadUint8()
+
+	for i := uint8(0); i < count; i++ {
+		m.CharIDs = append(m.CharIDs, bf.ReadUint32())
+	}
+
+	return nil
 }
 
 // Build builds a binary packet from the current data.
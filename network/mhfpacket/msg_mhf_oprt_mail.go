@@ -8,7 +8,8 @@ import (
 type OperateMailOperation uint8
 
 const (
-	OperateMailOperationDelete OperateMailOperation = 0x01
+	OperateMailOperationDelete      OperateMailOperation = 0x01
+	OperateMailOperationAcquireItem OperateMailOperation = 0x05
 )
 
 // MsgMhfOprtMail represents the MSG_MHF_OPRT_MAIL
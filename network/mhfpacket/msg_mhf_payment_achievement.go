@@ -6,7 +6,11 @@ import (
 )
 
 // MsgMhfPaymentAchievement represents the MSG_MHF_PAYMENT_ACHIEVEMENT
-type MsgMhfPaymentAchievement struct{}
+type MsgMhfPaymentAchievement struct {
+	AckHandle     uint32
+	AchievementID uint8
+	Unk0          uint16
+}
 
 // Opcode returns the ID associated with this packet type.
 func (m *MsgMhfPaymentAchievement) Opcode() network.PacketID {
@@ -15,7 +19,10 @@ func (m *MsgMhfPaymentAchievement) Opcode() network.PacketID {
 
 // Parse parses the packet from binary
 func (m *MsgMhfPaymentAchievement) Parse(bf *byteframe.ByteFrame) error {
-	panic("Not implemented")
+	m.AckHandle = bf.ReadUint32()
+	m.AchievementID = bf.ReadUint8()
+	m.Unk0 = bf.ReadUint16()
+	return nil
 }
 
 // Build builds a binary packet from the current data.
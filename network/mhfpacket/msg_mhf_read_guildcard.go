@@ -8,6 +8,7 @@ import (
 // MsgMhfReadGuildcard represents the MSG_MHF_READ_GUILDCARD
 type MsgMhfReadGuildcard struct {
 	AckHandle uint32
+	CharID    uint32
 }
 
 // Opcode returns the ID associated with this packet type.
@@ -18,6 +19,7 @@ func (m *MsgMhfReadGuildcard) Opcode() network.PacketID {
 // Parse parses the packet from binary
 func (m *MsgMhfReadGuildcard) Parse(bf *byteframe.ByteFrame) error {
 	m.AckHandle = bf.ReadUint32()
+	m.CharID = bf.ReadUint32()
 	return nil
 }
 
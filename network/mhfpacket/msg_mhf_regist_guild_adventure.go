@@ -6,7 +6,11 @@ import (
 )
 
 // MsgMhfRegistGuildAdventure represents the MSG_MHF_REGIST_GUILD_ADVENTURE
-type MsgMhfRegistGuildAdventure struct{}
+type MsgMhfRegistGuildAdventure struct {
+	AckHandle   uint32
+	Destination uint32
+	CharID      uint32
+}
 
 // Opcode returns the ID associated with this packet type.
 func (m *MsgMhfRegistGuildAdventure) Opcode() network.PacketID {
@@ -15,7 +19,11 @@ func (m *MsgMhfRegistGuildAdventure) Opcode() network.PacketID {
 
 // Parse parses the packet from binary
 func (m *MsgMhfRegistGuildAdventure) Parse(bf *byteframe.ByteFrame) error {
-	panic("Not implemented")
+	m.AckHandle = bf.ReadUint32()
+	m.Destination = bf.ReadUint32()
+	m.CharID = bf.ReadUint32()
+
+	return nil
 }
 
 // Build builds a binary packet from the current data.
@@ -6,11 +6,11 @@ import (
 )
 
 // MsgMhfRegistGuildCooking represents the MSG_MHF_REGIST_GUILD_COOKING
-type MsgMhfRegistGuildCooking struct{
-	AckHandle      uint32
-	Unk0           uint32
-	Unk1           uint16
-	Unk2           uint8
+type MsgMhfRegistGuildCooking struct {
+	AckHandle   uint32
+	OverwriteID uint32
+	MealID      uint16
+	Success     uint8
 }
 
 // Opcode returns the ID associated with this packet type.
@@ -21,9 +21,9 @@ func (m *MsgMhfRegistGuildCooking) Opcode() network.PacketID {
 // Parse parses the packet from binary
 func (m *MsgMhfRegistGuildCooking) Parse(bf *byteframe.ByteFrame) error {
 	m.AckHandle = bf.ReadUint32()
-	m.Unk0 = bf.ReadUint32()
-	m.Unk1 = bf.ReadUint16()
-	m.Unk2 = bf.ReadUint8()
+	m.OverwriteID = bf.ReadUint32()
+	m.MealID = bf.ReadUint16()
+	m.Success = bf.ReadUint8()
 	return nil
 }
 
@@ -6,7 +6,10 @@ import (
 )
 
 // MsgMhfRegistGuildTresure represents the MSG_MHF_REGIST_GUILD_TRESURE
-type MsgMhfRegistGuildTresure struct{}
+type MsgMhfRegistGuildTresure struct {
+	AckHandle uint32
+	Data      []byte
+}
 
 // Opcode returns the ID associated with this packet type.
 func (m *MsgMhfRegistGuildTresure) Opcode() network.PacketID {
@@ -15,7 +18,10 @@ func (m *MsgMhfRegistGuildTresure) Opcode() network.PacketID {
 
 // Parse parses the packet from binary
 func (m *MsgMhfRegistGuildTresure) Parse(bf *byteframe.ByteFrame) error {
-	panic("Not implemented")
+	m.AckHandle = bf.ReadUint32()
+	m.Data = bf.ReadBytes(uint(bf.ReadUint16()))
+
+	return nil
 }
 
 // Build builds a binary packet from the current data.
@@ -6,7 +6,11 @@ import (
 )
 
 // MsgMhfResetAchievement represents the MSG_MHF_RESET_ACHIEVEMENT
-type MsgMhfResetAchievement struct{}
+type MsgMhfResetAchievement struct {
+	AckHandle     uint32
+	AchievementID uint8
+	Unk0          uint16
+}
 
 // Opcode returns the ID associated with this packet type.
 func (m *MsgMhfResetAchievement) Opcode() network.PacketID {
@@ -15,7 +19,10 @@ func (m *MsgMhfResetAchievement) Opcode() network.PacketID {
 
 // Parse parses the packet from binary
 func (m *MsgMhfResetAchievement) Parse(bf *byteframe.ByteFrame) error {
-	panic("Not implemented")
+	m.AckHandle = bf.ReadUint32()
+	m.AchievementID = bf.ReadUint8()
+	m.Unk0 = bf.ReadUint16()
+	return nil
 }
 
 // Build builds a binary packet from the current data.
@@ -6,7 +6,9 @@ import (
 )
 
 // MsgMhfResetTitle represents the MSG_MHF_RESET_TITLE
-type MsgMhfResetTitle struct{}
+type MsgMhfResetTitle struct {
+	AckHandle uint32
+}
 
 // Opcode returns the ID associated with this packet type.
 func (m *MsgMhfResetTitle) Opcode() network.PacketID {
@@ -15,7 +17,8 @@ func (m *MsgMhfResetTitle) Opcode() network.PacketID {
 
 // Parse parses the packet from binary
 func (m *MsgMhfResetTitle) Parse(bf *byteframe.ByteFrame) error {
-	panic("Not implemented")
+	m.AckHandle = bf.ReadUint32()
+	return nil
 }
 
 // Build builds a binary packet from the current data.
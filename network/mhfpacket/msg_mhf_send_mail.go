@@ -6,7 +6,18 @@ import (
 )
 
 // MsgMhfSendMail represents the MSG_MHF_SEND_MAIL
-type MsgMhfSendMail struct{}
+type MsgMhfSendMail struct {
+	AckHandle uint32
+
+	// RecipientID is 0 when the mail is sent to the whole guild
+	RecipientID   uint32
+	SubjectLength uint16
+	BodyLength    uint16
+	Quantity      uint32
+	ItemID        uint16
+	Subject       []byte
+	Body          []byte
+}
 
 // Opcode returns the ID associated with this packet type.
 func (m *MsgMhfSendMail) Opcode() network.PacketID {
@@ -15,7 +26,15 @@ func (m *MsgMhfSendMail) Opcode() network.PacketID {
 
 // Parse parses the packet from binary
 func (m *MsgMhfSendMail) Parse(bf *byteframe.ByteFrame) error {
-	panic("Not implemented")
+	m.AckHandle = bf.ReadUint32()
+	m.RecipientID = bf.ReadUint32()
+	m.SubjectLength = bf.ReadUint16()
+	m.BodyLength = bf.ReadUint16()
+	m.Quantity = bf.ReadUint32()
+	m.ItemID = bf.ReadUint16()
+	m.Subject = bf.ReadBytes(uint(m.SubjectLength))
+	m.Body = bf.ReadBytes(uint(m.BodyLength))
+	return nil
 }
 
 // Build builds a binary packet from the current data.
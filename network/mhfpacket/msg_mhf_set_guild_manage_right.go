@@ -6,7 +6,12 @@ import (
 )
 
 // MsgMhfSetGuildManageRight represents the MSG_MHF_SET_GUILD_MANAGE_RIGHT
-type MsgMhfSetGuildManageRight struct{}
+type MsgMhfSetGuildManageRight struct {
+	AckHandle uint32
+	CharID    uint32
+	Allowed   bool
+	Unk       []byte
+}
 
 // Opcode returns the ID associated with this packet type.
 func (m *MsgMhfSetGuildManageRight) Opcode() network.PacketID {
@@ -15,7 +20,12 @@ func (m *MsgMhfSetGuildManageRight) Opcode() network.PacketID {
 
 // Parse parses the packet from binary
 func (m *MsgMhfSetGuildManageRight) Parse(bf *byteframe.ByteFrame) error {
-	panic("Not implemented")
+	m.AckHandle = bf.ReadUint32()
+	m.CharID = bf.ReadUint32()
+	m.Allowed = bf.ReadBool()
+	m.Unk = bf.ReadBytes(3)
+
+	return nil
 }
 
 // Build builds a binary packet from the current data.
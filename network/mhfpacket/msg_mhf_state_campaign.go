@@ -6,7 +6,11 @@ import (
 )
 
 // MsgMhfStateCampaign represents the MSG_MHF_STATE_CAMPAIGN
-type MsgMhfStateCampaign struct{}
+type MsgMhfStateCampaign struct {
+	AckHandle  uint32
+	CampaignID uint32
+	Unk0       uint16
+}
 
 // Opcode returns the ID associated with this packet type.
 func (m *MsgMhfStateCampaign) Opcode() network.PacketID {
@@ -15,7 +19,10 @@ func (m *MsgMhfStateCampaign) Opcode() network.PacketID {
 
 // Parse parses the packet from binary
 func (m *MsgMhfStateCampaign) Parse(bf *byteframe.ByteFrame) error {
-	panic("Not implemented")
+	m.AckHandle = bf.ReadUint32()
+	m.CampaignID = bf.ReadUint32()
+	m.Unk0 = bf.ReadUint16()
+	return nil
 }
 
 // Build builds a binary packet from the current data.
@@ -6,7 +6,9 @@ import (
 )
 
 // MsgMhfUpdateGuild represents the MSG_MHF_UPDATE_GUILD
-type MsgMhfUpdateGuild struct{}
+type MsgMhfUpdateGuild struct {
+	AckHandle uint32
+}
 
 // Opcode returns the ID associated with this packet type.
 func (m *MsgMhfUpdateGuild) Opcode() network.PacketID {
@@ -15,7 +17,9 @@ func (m *MsgMhfUpdateGuild) Opcode() network.PacketID {
 
 // Parse parses the packet from binary
 func (m *MsgMhfUpdateGuild) Parse(bf *byteframe.ByteFrame) error {
-	panic("Not implemented")
+	m.AckHandle = bf.ReadUint32()
+
+	return nil
 }
 
 // Build builds a binary packet from the current data.
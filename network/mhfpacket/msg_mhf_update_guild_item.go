@@ -6,7 +6,12 @@ import (
 )
 
 // MsgMhfUpdateGuildItem represents the MSG_MHF_UPDATE_GUILD_ITEM
-type MsgMhfUpdateGuildItem struct{}
+type MsgMhfUpdateGuildItem struct {
+	AckHandle    uint32
+	GuildID      uint32
+	Unk0         uint16 // Zeroed
+	UpdatedItems []*WarehouseItem
+}
 
 // Opcode returns the ID associated with this packet type.
 func (m *MsgMhfUpdateGuildItem) Opcode() network.PacketID {
@@ -15,7 +20,14 @@ func (m *MsgMhfUpdateGuildItem) Opcode() network.PacketID {
 
 // Parse parses the packet from binary
 func (m *MsgMhfUpdateGuildItem) Parse(bf *byteframe.ByteFrame) error {
-	panic("Not implemented")
+	m.AckHandle = bf.ReadUint32()
+	m.GuildID = bf.ReadUint32()
+	changes := bf.ReadUint16()
+	m.Unk0 = bf.ReadUint16()
+	for i := 0; i < int(changes); i++ {
+		m.UpdatedItems = append(m.UpdatedItems, ReadWarehouseItem(bf))
+	}
+	return nil
 }
 
 // Build builds a binary packet from the current data.
@@ -6,7 +6,13 @@ import (
 )
 
 // MsgMhfUpdateHouse represents the MSG_MHF_UPDATE_HOUSE
-type MsgMhfUpdateHouse struct{}
+type MsgMhfUpdateHouse struct {
+	AckHandle uint32
+	State     uint8 // 1 = everyone, 2 = friends, 3 = guild
+	Unk0      uint8
+	Unk1      uint16
+	Password  string // Shift-JIS, empty when not password protected
+}
 
 // Opcode returns the ID associated with this packet type.
 func (m *MsgMhfUpdateHouse) Opcode() network.PacketID {
@@ -15,7 +21,12 @@ func (m *MsgMhfUpdateHouse) Opcode() network.PacketID {
 
 // Parse parses the packet from binary
 func (m *MsgMhfUpdateHouse) Parse(bf *byteframe.ByteFrame) error {
-	panic("Not implemented")
+	m.AckHandle = bf.ReadUint32()
+	m.State = bf.ReadUint8()
+	m.Unk0 = bf.ReadUint8()
+	m.Unk1 = bf.ReadUint16()
+	m.Password = string(bf.ReadNullTerminatedBytes())
+	return nil
 }
 
 // Build builds a binary packet from the current data.
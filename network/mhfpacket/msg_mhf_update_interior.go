@@ -6,7 +6,10 @@ import (
 )
 
 // MsgMhfUpdateInterior represents the MSG_MHF_UPDATE_INTERIOR
-type MsgMhfUpdateInterior struct{}
+type MsgMhfUpdateInterior struct {
+	AckHandle    uint32
+	InteriorData []byte
+}
 
 // Opcode returns the ID associated with this packet type.
 func (m *MsgMhfUpdateInterior) Opcode() network.PacketID {
@@ -15,7 +18,9 @@ func (m *MsgMhfUpdateInterior) Opcode() network.PacketID {
 
 // Parse parses the packet from binary
 func (m *MsgMhfUpdateInterior) Parse(bf *byteframe.ByteFrame) error {
-	panic("Not implemented")
+	m.AckHandle = bf.ReadUint32()
+	m.InteriorData = bf.ReadBytes(20)
+	return nil
 }
 
 // Build builds a binary packet from the current data.
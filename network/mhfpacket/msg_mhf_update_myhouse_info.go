@@ -8,7 +8,7 @@ import (
 // MsgMhfUpdateMyhouseInfo represents the MSG_MHF_UPDATE_MYHOUSE_INFO
 type MsgMhfUpdateMyhouseInfo struct {
 	AckHandle uint32
-	Unk0      []byte
+	Data      []byte
 }
 
 // Opcode returns the ID associated with this packet type.
@@ -19,7 +19,7 @@ func (m *MsgMhfUpdateMyhouseInfo) Opcode() network.PacketID {
 // Parse parses the packet from binary
 func (m *MsgMhfUpdateMyhouseInfo) Parse(bf *byteframe.ByteFrame) error {
 	m.AckHandle = bf.ReadUint32()
-	m.Unk0 = bf.ReadBytes(0x16A)
+	m.Data = bf.ReadBytes(0x16A)
 	return nil
 }
 
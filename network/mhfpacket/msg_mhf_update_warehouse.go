@@ -5,8 +5,78 @@ import (
 	"github.com/Andoryuuta/byteframe"
 )
 
+// Sizes of the warehouse entries sent by the client.
+const (
+	WarehouseItemSize          = 12
+	WarehouseEquipmentSize     = 66
+	WarehouseEquipmentDataSize = WarehouseEquipmentSize - 8
+)
+
+// WarehouseItem is a single item stack in a warehouse item box.
+// The client sends a WarehouseID of 0 for newly stored stacks and a Quantity of 0 for removed ones.
+type WarehouseItem struct {
+	WarehouseID uint32
+	ItemID      uint16
+	Quantity    uint16
+	Unk0        uint32
+}
+
+// WarehouseEquipment is a single piece of equipment in a warehouse equipment box.
+// The client sends a WarehouseID of 0 for newly stored equipment and an ItemID of 0 for removed ones.
+type WarehouseEquipment struct {
+	WarehouseID uint32
+	ItemType    uint8
+	Unk0        uint8
+	ItemID      uint16
+	Data        []byte // Level, decorations and sigils
+}
+
+// ReadWarehouseItem reads a warehouse item stack.
+func ReadWarehouseItem(bf *byteframe.ByteFrame) *WarehouseItem {
+	return &WarehouseItem{
+		WarehouseID: bf.ReadUint32(),
+		ItemID:      bf.ReadUint16(),
+		Quantity:    bf.ReadUint16(),
+		Unk0:        bf.ReadUint32(),
+	}
+}
+
+// Write writes the item stack in the format sent by the client.
+func (i *WarehouseItem) Write(bf *byteframe.ByteFrame) {
+	bf.WriteUint32(i.WarehouseID)
+	bf.WriteUint16(i.ItemID)
+	bf.WriteUint16(i.Quantity)
+	bf.WriteUint32(i.Unk0)
+}
+
+// ReadWarehouseEquipment reads a warehouse equipment entry.
+func ReadWarehouseEquipment(bf *byteframe.ByteFrame) *WarehouseEquipment {
+	return &WarehouseEquipment{
+		WarehouseID: bf.ReadUint32(),
+		ItemType:    bf.ReadUint8(),
+		Unk0:        bf.ReadUint8(),
+		ItemID:      bf.ReadUint16(),
+		Data:        bf.ReadBytes(WarehouseEquipmentDataSize),
+	}
+}
+
+// Write writes the equipment entry in the format sent by the client.
+func (e *WarehouseEquipment) Write(bf *byteframe.ByteFrame) {
+	bf.WriteUint32(e.WarehouseID)
+	bf.WriteUint8(e.ItemType)
+	bf.WriteUint8(e.Unk0)
+	bf.WriteUint16(e.ItemID)
+	bf.WriteBytes(e.Data)
+}
+
 // MsgMhfUpdateWarehouse represents the MSG_MHF_UPDATE_WAREHOUSE
-type MsgMhfUpdateWarehouse struct{}
+type MsgMhfUpdateWarehouse struct {
+	AckHandle        uint32
+	BoxType          uint8 // 0 = items, 1 = equipment
+	BoxIndex         uint8
+	UpdatedItems     []*WarehouseItem
+	UpdatedEquipment []*WarehouseEquipment
+}
 
 // Opcode returns the ID associated with this packet type.
 func (m *MsgMhfUpdateWarehouse) Opcode() network.PacketID {
@@ -15,7 +85,18 @@ func (m *MsgMhfUpdateWarehouse) Opcode() network.PacketID {
 
 // Parse parses the packet from binary
 func (m *MsgMhfUpdateWarehouse) Parse(bf *byteframe.ByteFrame) error {
-	panic("Not implemented")
+	m.AckHandle = bf.ReadUint32()
+	m.BoxType = bf.ReadUint8()
+	m.BoxIndex = bf.ReadUint8()
+	changes := bf.ReadUint16()
+	for i := 0; i < int(changes); i++ {
+		if m.BoxType == 0 {
+			m.UpdatedItems = append(m.UpdatedItems, ReadWarehouseItem(bf))
+		} else {
+			m.UpdatedEquipment = append(m.UpdatedEquipment, ReadWarehouseEquipment(bf))
+		}
+	}
+	return nil
 }
 
 // Build builds a binary packet from the current data.
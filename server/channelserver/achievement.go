@@ -0,0 +1,238 @@
+package channelserver
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// Number of achievements the client expects in response to MSG_MHF_GET_ACHIEVEMENT.
+const AchievementCount = 60
+
+// Points required for each of the 8 ranks of an achievement, relative to the previous rank.
+var achievementCurves = [][]uint32{
+	{5, 15, 30, 50, 100, 150, 200, 300},
+	{1, 5, 10, 15, 30, 50, 75, 100},
+	{1, 2, 3, 4, 5, 6, 7, 8},
+	{150, 350, 500, 800, 1200, 2000, 3000, 5000},
+}
+
+// Achievements that don't use the first curve.
+var achievementCurveIDs = map[uint8]int{
+	7: 1, 27: 1, 28: 1,
+	8:  2,
+	16: 3, 17: 3, 18: 3, 19: 3, 20: 3, 21: 3, 22: 3, 23: 3, 24: 3, 25: 3, 26: 3, 29: 3, 30: 3, 31: 3, 32: 3,
+}
+
+type Achievement struct {
+	ID            uint8  `db:"achievement_id"`
+	Progress      uint32 `db:"progress"`
+	DisplayedRank uint8  `db:"displayed_rank"`
+	PaidRank      uint8  `db:"paid_rank"`
+}
+
+// Rank returns the reached rank, the points still needed for the next one and the progress within the current one.
+func (a *Achievement) Rank() (rank uint8, next uint32, progress uint32) {
+	curve := achievementCurves[achievementCurveIDs[a.ID]]
+	score := a.Progress
+
+	for _, required := range curve {
+		if score < required {
+			return rank, required - score, score
+		}
+
+		score -= required
+		rank++
+	}
+
+	return rank, 0, curve[len(curve)-1]
+}
+
+// GetAchievements returns every achievement for the character, including ones without progress.
+func GetAchievements(s *Session, charID uint32) ([]*Achievement, error) {
+	rows, err := s.server.db.Queryx(`
+		SELECT achievement_id, progress, displayed_rank, paid_rank FROM achievements WHERE character_id = $1
+	`, charID)
+
+	if err != nil {
+		s.logger.Error("failed to retrieve achievements", zap.Error(err), zap.Uint32("charID", charID))
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	achievements := make([]*Achievement, AchievementCount)
+
+	for i := range achievements {
+		achievements[i] = &Achievement{ID: uint8(i)}
+	}
+
+	for rows.Next() {
+		achievement := &Achievement{}
+
+		err = rows.StructScan(achievement)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if int(achievement.ID) < AchievementCount {
+			achievements[achievement.ID] = achievement
+		}
+	}
+
+	return achievements, nil
+}
+
+func GetAchievement(s *Session, charID uint32, achievementID uint8) (*Achievement, error) {
+	achievements, err := GetAchievements(s, charID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if int(achievementID) >= len(achievements) {
+		return nil, fmt.Errorf("invalid achievement ID %d", achievementID)
+	}
+
+	return achievements[achievementID], nil
+}
+
+func AddAchievementProgress(s *Session, charID uint32, achievementID uint8) error {
+	if achievementID >= AchievementCount {
+		return fmt.Errorf("invalid achievement ID %d", achievementID)
+	}
+
+	_, err := s.server.db.Exec(`
+		INSERT INTO achievements (character_id, achievement_id, progress) VALUES ($1, $2, 1)
+		ON CONFLICT (character_id, achievement_id) DO UPDATE SET progress = achievements.progress + 1
+	`, charID, achievementID)
+
+	if err != nil {
+		s.logger.Error(
+			"failed to add achievement progress",
+			zap.Error(err),
+			zap.Uint32("charID", charID),
+			zap.Uint8("achievementID", achievementID),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (a *Achievement) Save(s *Session, charID uint32) error {
+	_, err := s.server.db.Exec(`
+		INSERT INTO achievements (character_id, achievement_id, progress, displayed_rank, paid_rank) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (character_id, achievement_id) DO UPDATE
+			SET progress = excluded.progress, displayed_rank = excluded.displayed_rank,
+				paid_rank = GREATEST(achievements.paid_rank, excluded.paid_rank)
+	`, charID, a.ID, a.Progress, a.DisplayedRank, a.PaidRank)
+
+	if err != nil {
+		s.logger.Error(
+			"failed to save achievement",
+			zap.Error(err),
+			zap.Uint32("charID", charID),
+			zap.Uint8("achievementID", a.ID),
+		)
+		return err
+	}
+
+	return nil
+}
+
+// Rewards returns the configured rewards for every rank reached but not yet paid.
+func (a *Achievement) Rewards(s *Session) ([]DistributionItem, error) {
+	rank, _, _ := a.Rank()
+	items := make([]DistributionItem, 0)
+
+	err := s.server.db.Select(&items, `
+		SELECT item_type, item_id, quantity FROM achievement_rewards
+		WHERE achievement_id = $1 AND rank > $2 AND rank <= $3
+		ORDER BY rank
+	`, a.ID, a.PaidRank, rank)
+
+	if err != nil {
+		s.logger.Error("failed to retrieve achievement rewards", zap.Error(err), zap.Uint8("achievementID", a.ID))
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// PayRewards sends the rewards for every rank reached but not yet paid as a distribution and records the paid rank.
+// Both happen in one transaction, and the paid rank only moves forward, so rewards can't be paid twice.
+func (a *Achievement) PayRewards(s *Session, charID uint32) error {
+	rank, _, _ := a.Rank()
+
+	if rank <= a.PaidRank {
+		return nil
+	}
+
+	rewards, err := a.Rewards(s)
+
+	if err != nil {
+		return err
+	}
+
+	transaction, err := s.server.db.Begin()
+
+	if err != nil {
+		s.logger.Error("failed to start db transaction", zap.Error(err))
+		return err
+	}
+
+	result, err := transaction.Exec(`
+		INSERT INTO achievements (character_id, achievement_id, progress, displayed_rank, paid_rank) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (character_id, achievement_id) DO UPDATE
+			SET paid_rank = excluded.paid_rank
+			WHERE achievements.paid_rank < excluded.paid_rank
+	`, charID, a.ID, a.Progress, a.DisplayedRank, rank)
+
+	if err != nil {
+		s.logger.Error(
+			"failed to save achievement paid rank",
+			zap.Error(err),
+			zap.Uint32("charID", charID),
+			zap.Uint8("achievementID", a.ID),
+		)
+		rollbackTransaction(s, transaction)
+		return err
+	}
+
+	updated, err := result.RowsAffected()
+
+	if err != nil || updated == 0 {
+		// Already paid by a concurrent request
+		rollbackTransaction(s, transaction)
+		return err
+	}
+
+	if len(rewards) > 0 {
+		err = createCharacterDistribution(
+			s,
+			transaction,
+			charID,
+			"Achievement Reward",
+			fmt.Sprintf("Reward for reaching rank %d of achievement %d.", rank, a.ID),
+			rewards,
+		)
+
+		if err != nil {
+			rollbackTransaction(s, transaction)
+			return err
+		}
+	}
+
+	err = transaction.Commit()
+
+	if err != nil {
+		s.logger.Error("failed to commit db transaction", zap.Error(err))
+		return err
+	}
+
+	a.PaidRank = rank
+
+	return nil
+}
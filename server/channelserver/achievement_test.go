@@ -0,0 +1,37 @@
+package channelserver
+
+import (
+	"testing"
+)
+
+func TestAchievementRank(t *testing.T) {
+	tests := []struct {
+		id           uint8
+		progress     uint32
+		wantRank     uint8
+		wantNext     uint32
+		wantProgress uint32
+	}{
+		{0, 0, 0, 5, 0},
+		{0, 4, 0, 1, 4},
+		{0, 5, 1, 15, 0},
+		{0, 25, 2, 25, 5},
+		{0, 850, 8, 0, 300},
+		{0, 100000, 8, 0, 300},
+		{7, 1, 1, 5, 0},
+		{8, 3, 2, 3, 0},
+		{16, 149, 0, 1, 149},
+	}
+
+	for _, tt := range tests {
+		a := &Achievement{ID: tt.id, Progress: tt.progress}
+		rank, next, progress := a.Rank()
+
+		if rank != tt.wantRank || next != tt.wantNext || progress != tt.wantProgress {
+			t.Errorf(
+				"achievement %d with progress %d: got rank %d, next %d, progress %d, want %d, %d, %d",
+				tt.id, tt.progress, rank, next, progress, tt.wantRank, tt.wantNext, tt.wantProgress,
+			)
+		}
+	}
+}
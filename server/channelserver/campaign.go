@@ -0,0 +1,160 @@
+package channelserver
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type Event struct {
+	ID        uint32    `db:"id"`
+	EventType uint16    `db:"event_type"`
+	StartTime time.Time `db:"start_time"`
+	EndTime   time.Time `db:"end_time"`
+}
+
+type Campaign struct {
+	ID          uint32         `db:"id"`
+	Title       string         `db:"title"`
+	Description string         `db:"description"`
+	Code        sql.NullString `db:"code"`
+	MinHR       uint16         `db:"min_hr"`
+	MaxHR       uint16         `db:"max_hr"`
+	StartTime   time.Time      `db:"start_time"`
+	EndTime     time.Time      `db:"end_time"`
+	Active      bool           `db:"active"`
+	Applied     bool           `db:"applied"`
+}
+
+// Participation states sent in response to MSG_MHF_STATE_CAMPAIGN.
+const (
+	CampaignStateOpen = iota
+	CampaignStateApplied
+	CampaignStateClosed
+)
+
+const campaignSelectQuery = `
+	SELECT c.id, c.title, c.description, c.code, c.min_hr, c.max_hr, c.start_time, c.end_time,
+		c.start_time <= now() AND c.end_time > now() AS active,
+		EXISTS(SELECT 1 FROM campaign_entries ce WHERE ce.campaign_id = c.id AND ce.character_id = $1) AS applied
+	FROM campaigns c
+`
+
+// IsEligible checks the campaign's HR restriction against the character's HR.
+func (c *Campaign) IsEligible(hr uint16) bool {
+	return hr >= c.MinHR && hr <= c.MaxHR
+}
+
+func (c *Campaign) State(hr uint16) uint8 {
+	if c.Applied {
+		return CampaignStateApplied
+	}
+
+	if !c.Active || !c.IsEligible(hr) {
+		return CampaignStateClosed
+	}
+
+	return CampaignStateOpen
+}
+
+func (c *Campaign) Apply(s *Session) error {
+	_, err := s.server.db.Exec(`
+		INSERT INTO campaign_entries (campaign_id, character_id) VALUES ($1, $2)
+	`, c.ID, s.charID)
+
+	if err != nil {
+		s.logger.Error(
+			"failed to apply for campaign",
+			zap.Error(err),
+			zap.Uint32("campaignID", c.ID),
+			zap.Uint32("charID", s.charID),
+		)
+		return err
+	}
+
+	c.Applied = true
+
+	return nil
+}
+
+func GetActiveEvents(s *Session) ([]*Event, error) {
+	events := make([]*Event, 0)
+
+	err := s.server.db.Select(&events, `
+		SELECT id, event_type, start_time, end_time FROM events
+		WHERE start_time <= now() AND end_time > now()
+		ORDER BY start_time, id
+	`)
+
+	if err != nil {
+		s.logger.Error("failed to retrieve events", zap.Error(err))
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// GetCampaignsForCharacter returns running campaigns the character is eligible for or has already applied to.
+func GetCampaignsForCharacter(s *Session, charID uint32) ([]*Campaign, error) {
+	hr, err := getCharacterHR(s, charID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	campaigns := make([]*Campaign, 0)
+
+	err = s.server.db.Select(&campaigns, campaignSelectQuery+`
+		WHERE c.start_time <= now() AND c.end_time > now()
+		ORDER BY c.start_time, c.id
+	`, charID)
+
+	if err != nil {
+		s.logger.Error("failed to retrieve campaigns", zap.Error(err), zap.Uint32("charID", charID))
+		return nil, err
+	}
+
+	filtered := make([]*Campaign, 0, len(campaigns))
+
+	for _, c := range campaigns {
+		if c.Applied || c.IsEligible(hr) {
+			filtered = append(filtered, c)
+		}
+	}
+
+	return filtered, nil
+}
+
+func GetCampaignByID(s *Session, charID uint32, campaignID uint32) (*Campaign, error) {
+	campaign := &Campaign{}
+
+	err := s.server.db.QueryRowx(campaignSelectQuery+`
+		WHERE c.id = $2
+	`, charID, campaignID).StructScan(campaign)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		s.logger.Error("failed to retrieve campaign", zap.Error(err), zap.Uint32("campaignID", campaignID))
+		return nil, err
+	}
+
+	return campaign, nil
+}
+
+func getCharacterHR(s *Session, charID uint32) (uint16, error) {
+	var hr uint16
+
+	err := s.server.db.QueryRow("SELECT COALESCE(exp, 0) FROM characters WHERE id = $1", charID).Scan(&hr)
+
+	if err != nil {
+		s.logger.Error("failed to retrieve character HR", zap.Error(err), zap.Uint32("charID", charID))
+		return 0, err
+	}
+
+	return hr, nil
+}
@@ -0,0 +1,27 @@
+package channelserver
+
+import (
+	"testing"
+)
+
+func TestCampaignState(t *testing.T) {
+	tests := []struct {
+		campaign Campaign
+		hr       uint16
+		want     uint8
+	}{
+		{Campaign{MinHR: 1, MaxHR: 999, Active: true}, 50, CampaignStateOpen},
+		{Campaign{MinHR: 1, MaxHR: 999, Active: true}, 999, CampaignStateOpen},
+		{Campaign{MinHR: 100, MaxHR: 999, Active: true}, 50, CampaignStateClosed},
+		{Campaign{MinHR: 1, MaxHR: 30, Active: true}, 31, CampaignStateClosed},
+		{Campaign{MinHR: 1, MaxHR: 999, Active: false}, 50, CampaignStateClosed},
+		{Campaign{MinHR: 1, MaxHR: 999, Active: false, Applied: true}, 50, CampaignStateApplied},
+		{Campaign{MinHR: 100, MaxHR: 999, Active: true, Applied: true}, 50, CampaignStateApplied},
+	}
+
+	for i, tt := range tests {
+		if got := tt.campaign.State(tt.hr); got != tt.want {
+			t.Errorf("case %d: State(%d) = %d, want %d", i, tt.hr, got, tt.want)
+		}
+	}
+}
@@ -101,6 +101,8 @@ func (s *Server) Start() error {
 
 	go s.acceptClients()
 	go s.manageSessions()
+	go s.expireMail()
+	go s.replaceInactiveGuildLeaders()
 
 	// Start the discord bot for chat integration.
 	if s.erupeConfig.Discord.Enabled {
@@ -180,6 +182,11 @@ func (s *Server) manageSessions() {
 
 // BroadcastMHF queues a MHFPacket to be sent to all sessions.
 func (s *Server) BroadcastMHF(pkt mhfpacket.MHFPacket, ignoredSession *Session) {
+	s.BroadcastMHFExcept(pkt, ignoredSession, nil)
+}
+
+// BroadcastMHFExcept works like BroadcastMHF, also skipping the sessions of the excluded characters.
+func (s *Server) BroadcastMHFExcept(pkt mhfpacket.MHFPacket, ignoredSession *Session, excludedCharIDs map[uint32]bool) {
 	// Make the header
 	bf := byteframe.NewByteFrame()
 	bf.WriteUint16(uint16(pkt.Opcode()))
@@ -189,7 +196,7 @@ func (s *Server) BroadcastMHF(pkt mhfpacket.MHFPacket, ignoredSession *Session)
 
 	// Broadcast the data.
 	for _, session := range s.sessions {
-		if session == ignoredSession {
+		if session == ignoredSession || excludedCharIDs[session.charID] {
 			continue
 		}
 		// Enqueue in a non-blocking way that drops the packet if the connections send buffer channel is full.
@@ -233,3 +240,27 @@ func (s *Server) FindSessionByCharID(charID uint32) *Session {
 
 	return nil
 }
+
+// OnlineCharIDs returns the characters of every session that has entered a stage.
+func (s *Server) OnlineCharIDs() []uint32 {
+	s.stagesLock.RLock()
+	defer s.stagesLock.RUnlock()
+
+	deduped := make(map[uint32]bool)
+
+	for _, stage := range s.stages {
+		stage.RLock()
+		for client := range stage.clients {
+			deduped[client.charID] = true
+		}
+		stage.RUnlock()
+	}
+
+	charIDs := make([]uint32, 0, len(deduped))
+
+	for charID := range deduped {
+		charIDs = append(charIDs, charID)
+	}
+
+	return charIDs
+}
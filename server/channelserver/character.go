@@ -8,7 +8,14 @@ import (
 )
 
 const (
-	CharacterSaveRPPointer = 0x22D16
+	CharacterSaveRPPointer        = 0x22D16
+	CharacterSaveHouseTierPointer = 0x1FB6C
+	CharacterSaveHouseDataPointer = 0x1FE01
+)
+
+const (
+	CharacterSaveHouseTierSize = 5
+	CharacterSaveHouseDataSize = 195
 )
 
 type CharacterSaveData struct {
@@ -16,6 +23,8 @@ type CharacterSaveData struct {
 	Name           string
 	RP             uint16
 	IsNewCharacter bool
+	HouseTier      []byte
+	HouseData      []byte
 
 	// Use provided setter/getter
 	baseSaveData []byte
@@ -89,14 +98,14 @@ func (save *CharacterSaveData) Save(s *Session, transaction *sql.Tx) error {
 
 	updateSQL := `
 		UPDATE characters 
-			SET savedata=$1, is_new_character=$3
+			SET savedata=$1, is_new_character=$3, house_tier=$4, house_data=$5
 		WHERE id=$2
 	`
 
 	if transaction != nil {
-		_, err = transaction.Exec(updateSQL, compressedData, save.CharID, save.IsNewCharacter)
+		_, err = transaction.Exec(updateSQL, compressedData, save.CharID, save.IsNewCharacter, save.HouseTier, save.HouseData)
 	} else {
-		_, err = s.server.db.Exec(updateSQL, compressedData, save.CharID, save.IsNewCharacter)
+		_, err = s.server.db.Exec(updateSQL, compressedData, save.CharID, save.IsNewCharacter, save.HouseTier, save.HouseData)
 	}
 
 	if err != nil {
@@ -139,4 +148,6 @@ func (save *CharacterSaveData) updateSaveDataWithStruct() {
 // This will update the character save struct with the values stored in the raw savedata arrays
 func (save *CharacterSaveData) updateStructWithSaveData() {
 	save.RP = binary.LittleEndian.Uint16(save.baseSaveData[CharacterSaveRPPointer : CharacterSaveRPPointer+2])
+	save.HouseTier = save.baseSaveData[CharacterSaveHouseTierPointer : CharacterSaveHouseTierPointer+CharacterSaveHouseTierSize]
+	save.HouseData = save.baseSaveData[CharacterSaveHouseDataPointer : CharacterSaveHouseDataPointer+CharacterSaveHouseDataSize]
 }
@@ -0,0 +1,468 @@
+package channelserver
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Andoryuuta/Erupe/network/mhfpacket"
+	"github.com/Andoryuuta/byteframe"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// Chat messages starting with this prefix are handled as commands instead of being broadcast.
+const ChatCommandPrefix = "!"
+
+type UserRole string
+
+const (
+	UserRolePlayer    UserRole = "player"
+	UserRoleModerator UserRole = "moderator"
+	UserRoleAdmin     UserRole = "admin"
+)
+
+// Permission level of each role, a role can use every command up to its own level.
+var userRoleLevels = map[UserRole]int{
+	UserRolePlayer:    0,
+	UserRoleModerator: 1,
+	UserRoleAdmin:     2,
+}
+
+// Returned by command handlers when the arguments don't match the command's usage.
+var errChatCommandUsage = errors.New("invalid chat command arguments")
+
+type ChatCommand struct {
+	Name        string
+	Usage       string // Arguments, shown in help and usage errors
+	Description string
+	Role        UserRole // Lowest role allowed to use the command
+	Handler     func(s *Session, args []string) error
+}
+
+var chatCommands map[string]*ChatCommand
+
+func init() {
+	chatCommands = make(map[string]*ChatCommand)
+
+	registerChatCommand(&ChatCommand{
+		Name:        "help",
+		Usage:       "[command]",
+		Description: "Lists the commands you can use or explains one of them",
+		Role:        UserRolePlayer,
+		Handler:     chatCommandHelp,
+	})
+	registerChatCommand(&ChatCommand{
+		Name:        "tele",
+		Usage:       "<x> <y>",
+		Description: "Teleports you to a position in the current stage",
+		Role:        UserRolePlayer,
+		Handler:     chatCommandTeleport,
+	})
+	registerChatCommand(&ChatCommand{
+		Name:        "who",
+		Usage:       "[friends]",
+		Description: "Lists the players on this channel, or your friends and whether they're on this channel",
+		Role:        UserRolePlayer,
+		Handler:     chatCommandWho,
+	})
+	registerChatCommand(&ChatCommand{
+		Name:        "reload",
+		Usage:       "",
+		Description: "Reloads the other players in your stage",
+		Role:        UserRolePlayer,
+		Handler:     chatCommandReload,
+	})
+	registerChatCommand(&ChatCommand{
+		Name:        "kick",
+		Usage:       "<character ID>",
+		Description: "Disconnects a player",
+		Role:        UserRoleModerator,
+		Handler:     chatCommandKick,
+	})
+	registerChatCommand(&ChatCommand{
+		Name:        "announce",
+		Usage:       "<message>",
+		Description: "Sends a message to every player",
+		Role:        UserRoleModerator,
+		Handler:     chatCommandAnnounce,
+	})
+	registerChatCommand(&ChatCommand{
+		Name:        "item",
+		Usage:       "<item ID> [amount] [character ID]",
+		Description: "Mails an item to you or another character",
+		Role:        UserRoleAdmin,
+		Handler:     chatCommandGiveItem,
+	})
+	registerChatCommand(&ChatCommand{
+		Name:        "logs",
+		Usage:       "<character ID> [count]",
+		Description: "Shows the most recent terminal log entries sent by a character",
+		Role:        UserRoleAdmin,
+		Handler:     chatCommandTerminalLogs,
+	})
+}
+
+func registerChatCommand(command *ChatCommand) {
+	if _, exists := chatCommands[command.Name]; exists {
+		panic(fmt.Sprintf("chat command '%s' registered twice", command.Name))
+	}
+
+	chatCommands[command.Name] = command
+}
+
+func (c *ChatCommand) UsageText() string {
+	return strings.TrimSpace(fmt.Sprintf("%s%s %s", ChatCommandPrefix, c.Name, c.Usage))
+}
+
+// CanUse reports whether the role is allowed to use the command.
+func (c *ChatCommand) CanUse(role UserRole) bool {
+	return userRoleLevels[role] >= userRoleLevels[c.Role]
+}
+
+func getUserRole(s *Session) (UserRole, error) {
+	var role UserRole
+
+	err := s.server.db.QueryRow(`
+		SELECT u.role FROM users u JOIN characters c ON c.user_id = u.id WHERE c.id = $1
+	`, s.charID).Scan(&role)
+
+	if err != nil {
+		s.logger.Error("failed to retrieve user role", zap.Error(err), zap.Uint32("charID", s.charID))
+		return "", err
+	}
+
+	return role, nil
+}
+
+// parseChatCommand splits a chat message into a registered command and its arguments, reporting false for regular chat.
+func parseChatCommand(message string) (*ChatCommand, []string, bool) {
+	if !strings.HasPrefix(message, ChatCommandPrefix) {
+		return nil, nil, false
+	}
+
+	fields := strings.Fields(strings.TrimPrefix(message, ChatCommandPrefix))
+
+	if len(fields) == 0 {
+		return nil, nil, false
+	}
+
+	// Anything else starting with the prefix is regular chat, like "!!!"
+	command, ok := chatCommands[strings.ToLower(fields[0])]
+
+	if !ok {
+		return nil, nil, false
+	}
+
+	return command, fields[1:], true
+}
+
+// handleChatCommand runs the command in a chat message, reporting whether the message was a command.
+func handleChatCommand(s *Session, message string) bool {
+	command, args, ok := parseChatCommand(message)
+
+	if !ok {
+		return false
+	}
+
+	role, err := getUserRole(s)
+
+	if err != nil {
+		sendServerChatMessage(s, "Failed to run command")
+		return true
+	}
+
+	if !command.CanUse(role) {
+		s.logger.Warn(fmt.Sprintf("character '%d' is attempting to use chat command '%s' without permission", s.charID, command.Name))
+		sendServerChatMessage(s, "You don't have permission to use this command")
+		return true
+	}
+
+	err = command.Handler(s, args)
+
+	if errors.Is(err, errChatCommandUsage) {
+		sendServerChatMessage(s, fmt.Sprintf("Usage: %s", command.UsageText()))
+	} else if err != nil {
+		s.logger.Warn("failed to run chat command", zap.Error(err), zap.String("command", command.Name))
+		sendServerChatMessage(s, fmt.Sprintf("Failed to run command: %s", err))
+	}
+
+	return true
+}
+
+func chatCommandHelp(s *Session, args []string) error {
+	role, err := getUserRole(s)
+
+	if err != nil {
+		return err
+	}
+
+	if len(args) > 0 {
+		command, ok := chatCommands[strings.ToLower(args[0])]
+
+		if !ok || !command.CanUse(role) {
+			return fmt.Errorf("unknown command '%s'", args[0])
+		}
+
+		sendServerChatMessage(s, fmt.Sprintf("%s: %s", command.UsageText(), command.Description))
+		return nil
+	}
+
+	names := make([]string, 0, len(chatCommands))
+
+	for name, command := range chatCommands {
+		if command.CanUse(role) {
+			names = append(names, ChatCommandPrefix+name)
+		}
+	}
+
+	sort.Strings(names)
+
+	sendServerChatMessage(s, fmt.Sprintf("Commands: %s", strings.Join(names, " ")))
+
+	return nil
+}
+
+func chatCommandTeleport(s *Session, args []string) error {
+	if len(args) != 2 {
+		return errChatCommandUsage
+	}
+
+	x, errX := strconv.ParseInt(args[0], 10, 16)
+	y, errY := strconv.ParseInt(args[1], 10, 16)
+
+	if errX != nil || errY != nil {
+		return errChatCommandUsage
+	}
+
+	sendServerChatMessage(s, fmt.Sprintf("Teleporting to %d %d", x, y))
+
+	// Make the inside of the casted binary
+	payload := byteframe.NewByteFrame()
+	payload.SetLE()
+	payload.WriteUint8(2)        // SetState type(position == 2)
+	payload.WriteInt16(int16(x)) // X
+	payload.WriteInt16(int16(y)) // Y
+
+	s.QueueSendMHF(&mhfpacket.MsgSysCastedBinary{
+		CharID:         s.charID,
+		MessageType:    BinaryMessageTypeState,
+		RawDataPayload: payload.Data(),
+	})
+
+	return nil
+}
+
+func chatCommandWho(s *Session, args []string) error {
+	if len(args) > 0 {
+		if strings.ToLower(args[0]) != "friends" {
+			return errChatCommandUsage
+		}
+
+		friends, err := GetContacts(s, s.charID, ContactListFriends)
+
+		if err != nil {
+			return err
+		}
+
+		if len(friends) == 0 {
+			sendServerChatMessage(s, "Your friends list is empty")
+			return nil
+		}
+
+		for _, friend := range friends {
+			status := "not on this channel"
+
+			if friend.Online {
+				status = "on this channel"
+			}
+
+			sendServerChatMessage(s, fmt.Sprintf("%s (%d): %s", friend.Name, friend.CharID, status))
+		}
+
+		return nil
+	}
+
+	charIDs := s.server.OnlineCharIDs()
+
+	var names []string
+
+	err := s.server.db.Select(&names, `
+		SELECT name FROM characters WHERE id = ANY($1) ORDER BY name
+	`, pq.Array(charIDs))
+
+	if err != nil {
+		return err
+	}
+
+	sendServerChatMessage(s, fmt.Sprintf("%d on this channel: %s", len(names), strings.Join(names, ", ")))
+
+	return nil
+}
+
+func chatCommandReload(s *Session, args []string) error {
+	if len(args) != 0 {
+		return errChatCommandUsage
+	}
+
+	s.Lock()
+	stage := s.stage
+	s.Unlock()
+
+	if stage == nil {
+		return errors.New("not in a stage")
+	}
+
+	stage.RLock()
+	for client := range stage.clients {
+		if client != s {
+			s.QueueSendMHF(&mhfpacket.MsgSysDeleteUser{
+				CharID: client.charID,
+			})
+		}
+	}
+	stage.RUnlock()
+
+	s.QueueSend(makeStageClientsNotification(stage))
+
+	sendServerChatMessage(s, "Reloaded the players in your stage")
+
+	return nil
+}
+
+func chatCommandKick(s *Session, args []string) error {
+	if len(args) != 1 {
+		return errChatCommandUsage
+	}
+
+	charID, err := strconv.ParseUint(args[0], 10, 32)
+
+	if err != nil {
+		return errChatCommandUsage
+	}
+
+	target := s.server.FindSessionByCharID(uint32(charID))
+
+	if target == nil {
+		return fmt.Errorf("character '%d' is not online", charID)
+	}
+
+	s.logger.Info("Character kicked player", zap.Uint32("charID", s.charID), zap.Uint32("targetID", target.charID))
+
+	// The target's own recv loop logs them out once it sees the connection closing.
+	_ = target.rawConn.Close()
+
+	sendServerChatMessage(s, fmt.Sprintf("Kicked character %d", charID))
+
+	return nil
+}
+
+func chatCommandAnnounce(s *Session, args []string) error {
+	if len(args) == 0 {
+		return errChatCommandUsage
+	}
+
+	s.server.BroadcastChatMessage(strings.Join(args, " "))
+
+	return nil
+}
+
+func chatCommandGiveItem(s *Session, args []string) error {
+	if len(args) < 1 || len(args) > 3 {
+		return errChatCommandUsage
+	}
+
+	itemID, err := strconv.ParseUint(args[0], 10, 16)
+
+	if err != nil {
+		return errChatCommandUsage
+	}
+
+	amount := int64(1)
+	recipientID := s.charID
+
+	if len(args) > 1 {
+		amount, err = strconv.ParseInt(args[1], 10, 16)
+
+		if err != nil || amount < 1 || amount > WarehouseMaxStackSize {
+			return errChatCommandUsage
+		}
+	}
+
+	if len(args) > 2 {
+		charID, err := strconv.ParseUint(args[2], 10, 32)
+
+		if err != nil {
+			return errChatCommandUsage
+		}
+
+		recipientID = uint32(charID)
+	}
+
+	item := uint16(itemID)
+
+	err = SendSystemMail(s, &Mail{
+		RecipientID:        recipientID,
+		Subject:            "Item delivery",
+		Body:               "An item has been delivered to you.",
+		AttachedItemID:     &item,
+		AttachedItemAmount: int16(amount),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	sendServerChatMessage(s, fmt.Sprintf("Mailed %d of item %d to character %d", amount, itemID, recipientID))
+
+	return nil
+}
+
+// Most terminal log entries the logs command prints at once.
+const chatCommandMaxTerminalLogs = 20
+
+func chatCommandTerminalLogs(s *Session, args []string) error {
+	if len(args) < 1 || len(args) > 2 {
+		return errChatCommandUsage
+	}
+
+	charID, err := strconv.ParseUint(args[0], 10, 32)
+
+	if err != nil {
+		return errChatCommandUsage
+	}
+
+	count := int64(5)
+
+	if len(args) > 1 {
+		count, err = strconv.ParseInt(args[1], 10, 32)
+
+		if err != nil || count < 1 || count > chatCommandMaxTerminalLogs {
+			return errChatCommandUsage
+		}
+	}
+
+	logs, err := GetTerminalLogsForCharacter(s, uint32(charID), int(count))
+
+	if err != nil {
+		return err
+	}
+
+	if len(logs) == 0 {
+		sendServerChatMessage(s, fmt.Sprintf("Character %d has no terminal logs", charID))
+		return nil
+	}
+
+	for _, log := range logs {
+		sendServerChatMessage(s, fmt.Sprintf(
+			"%s log %d: %d %d %d %d %d %d %d %d %d",
+			log.CreatedAt.Format("2006-01-02 15:04:05"),
+			log.LogID,
+			log.U0, log.U1, log.U2, log.U3, log.U4, log.U5, log.U6, log.U7, log.U8,
+		))
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package channelserver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseChatCommand(t *testing.T) {
+	tests := []struct {
+		message  string
+		wantName string
+		wantArgs []string
+		wantOK   bool
+	}{
+		{"!help", "help", []string{}, true},
+		{"!HELP tele", "help", []string{"tele"}, true},
+		{"!tele  10   -20 ", "tele", []string{"10", "-20"}, true},
+		{"!item 1 2 3", "item", []string{"1", "2", "3"}, true},
+		{"hello", "", nil, false},
+		{"!", "", nil, false},
+		{"! help", "help", []string{}, true},
+		{"!!! help", "", nil, false},
+		{"!unknown", "", nil, false},
+		{"help!", "", nil, false},
+	}
+
+	for _, tt := range tests {
+		command, args, ok := parseChatCommand(tt.message)
+
+		if ok != tt.wantOK {
+			t.Errorf("parseChatCommand(%q) ok = %v, want %v", tt.message, ok, tt.wantOK)
+			continue
+		}
+
+		if !ok {
+			continue
+		}
+
+		if command.Name != tt.wantName || !reflect.DeepEqual(args, tt.wantArgs) {
+			t.Errorf("parseChatCommand(%q) = %s %v, want %s %v", tt.message, command.Name, args, tt.wantName, tt.wantArgs)
+		}
+	}
+}
+
+func TestChatCommandCanUse(t *testing.T) {
+	tests := []struct {
+		commandRole UserRole
+		role        UserRole
+		want        bool
+	}{
+		{UserRolePlayer, UserRolePlayer, true},
+		{UserRolePlayer, UserRoleAdmin, true},
+		{UserRoleModerator, UserRolePlayer, false},
+		{UserRoleModerator, UserRoleModerator, true},
+		{UserRoleModerator, UserRoleAdmin, true},
+		{UserRoleAdmin, UserRoleModerator, false},
+		{UserRoleAdmin, UserRoleAdmin, true},
+		{UserRoleModerator, UserRole("unknown"), false},
+	}
+
+	for _, tt := range tests {
+		command := &ChatCommand{Role: tt.commandRole}
+
+		if got := command.CanUse(tt.role); got != tt.want {
+			t.Errorf("%s command used by %s: CanUse() = %v, want %v", tt.commandRole, tt.role, got, tt.want)
+		}
+	}
+}
+
+func TestChatCommandUsageText(t *testing.T) {
+	tests := []struct {
+		command ChatCommand
+		want    string
+	}{
+		{ChatCommand{Name: "reload"}, "!reload"},
+		{ChatCommand{Name: "tele", Usage: "<x> <y>"}, "!tele <x> <y>"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.command.UsageText(); got != tt.want {
+			t.Errorf("UsageText() = %q, want %q", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package channelserver
+
+import (
+	"fmt"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// Most characters a friends list or blacklist can hold, the sign in response counts friends in a single byte.
+const ContactListLimit = 255
+
+type ContactList string
+
+const (
+	ContactListFriends   ContactList = "friends"
+	ContactListBlacklist ContactList = "blacklist"
+)
+
+// Table and column holding each contact list.
+var contactListTables = map[ContactList]struct {
+	Table  string
+	Column string
+}{
+	ContactListFriends:   {"character_friends", "friend_id"},
+	ContactListBlacklist: {"character_blacklist", "blocked_id"},
+}
+
+type Contact struct {
+	CharID uint32 `db:"id"`
+	Name   string `db:"name"`
+
+	// Whether the character is currently in a stage on this channel server. Channel servers don't share sessions,
+	// so characters online on another channel are reported as offline.
+	Online bool
+}
+
+// GetContacts returns the characters on one of the character's contact lists, oldest first.
+func GetContacts(s *Session, charID uint32, list ContactList) ([]*Contact, error) {
+	table := contactListTables[list]
+	contacts := make([]*Contact, 0)
+
+	err := s.server.db.Select(&contacts, fmt.Sprintf(`
+		SELECT c.id, c.name FROM %s cl
+			JOIN characters c ON c.id = cl.%s
+		WHERE cl.character_id = $1
+		ORDER BY cl.created_at
+		LIMIT $2
+	`, table.Table, table.Column), charID, ContactListLimit)
+
+	if err != nil {
+		s.logger.Error(
+			"failed to retrieve contacts",
+			zap.Error(err),
+			zap.Uint32("charID", charID),
+			zap.String("list", string(list)),
+		)
+		return nil, err
+	}
+
+	for _, contact := range contacts {
+		contact.Online = s.server.FindSessionByCharID(contact.CharID) != nil
+	}
+
+	return contacts, nil
+}
+
+// AddContacts puts characters on one of the character's contact lists, blocking a character also unfriends them.
+func AddContacts(s *Session, charID uint32, list ContactList, contactIDs []uint32) error {
+	table := contactListTables[list]
+
+	transaction, err := s.server.db.Begin()
+
+	if err != nil {
+		s.logger.Error("failed to start db transaction", zap.Error(err))
+		return err
+	}
+
+	var count int
+
+	err = transaction.QueryRow(fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE character_id = $1`, table.Table), charID).Scan(&count)
+
+	if err != nil {
+		rollbackTransaction(s, transaction)
+		return err
+	}
+
+	if count+len(contactIDs) > ContactListLimit {
+		rollbackTransaction(s, transaction)
+		return fmt.Errorf("character '%d' %s list is full", charID, list)
+	}
+
+	_, err = transaction.Exec(fmt.Sprintf(`
+		INSERT INTO %s (character_id, %s)
+		SELECT $1, id FROM characters WHERE id = ANY($2) AND id != $1
+		ON CONFLICT DO NOTHING
+	`, table.Table, table.Column), charID, pq.Array(contactIDs))
+
+	if err != nil {
+		s.logger.Error(
+			"failed to add contacts",
+			zap.Error(err),
+			zap.Uint32("charID", charID),
+			zap.String("list", string(list)),
+		)
+		rollbackTransaction(s, transaction)
+		return err
+	}
+
+	if list == ContactListBlacklist {
+		_, err = transaction.Exec(`
+			DELETE FROM character_friends WHERE character_id = $1 AND friend_id = ANY($2)
+		`, charID, pq.Array(contactIDs))
+
+		if err != nil {
+			rollbackTransaction(s, transaction)
+			return err
+		}
+	}
+
+	err = transaction.Commit()
+
+	if err != nil {
+		s.logger.Error("failed to commit db transaction", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func RemoveContacts(s *Session, charID uint32, list ContactList, contactIDs []uint32) error {
+	table := contactListTables[list]
+
+	_, err := s.server.db.Exec(fmt.Sprintf(`
+		DELETE FROM %s WHERE character_id = $1 AND %s = ANY($2)
+	`, table.Table, table.Column), charID, pq.Array(contactIDs))
+
+	if err != nil {
+		s.logger.Error(
+			"failed to remove contacts",
+			zap.Error(err),
+			zap.Uint32("charID", charID),
+			zap.String("list", string(list)),
+		)
+		return err
+	}
+
+	return nil
+}
+
+// HasContact reports whether contactID is on one of the character's contact lists.
+func HasContact(s *Session, charID uint32, list ContactList, contactID uint32) (bool, error) {
+	table := contactListTables[list]
+
+	var exists bool
+
+	err := s.server.db.QueryRow(fmt.Sprintf(`
+		SELECT EXISTS(SELECT 1 FROM %s WHERE character_id = $1 AND %s = $2)
+	`, table.Table, table.Column), charID, contactID).Scan(&exists)
+
+	if err != nil {
+		s.logger.Error(
+			"failed to check contact",
+			zap.Error(err),
+			zap.Uint32("charID", charID),
+			zap.String("list", string(list)),
+		)
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// GetBlockingCharacters returns the characters who have the given character on their blacklist.
+func GetBlockingCharacters(s *Session, charID uint32) (map[uint32]bool, error) {
+	var blockerIDs []uint32
+
+	err := s.server.db.Select(&blockerIDs, `
+		SELECT character_id FROM character_blacklist WHERE blocked_id = $1
+	`, charID)
+
+	if err != nil {
+		s.logger.Error("failed to retrieve blocking characters", zap.Error(err), zap.Uint32("charID", charID))
+		return nil, err
+	}
+
+	blockers := make(map[uint32]bool, len(blockerIDs))
+
+	for _, blockerID := range blockerIDs {
+		blockers[blockerID] = true
+	}
+
+	return blockers, nil
+}
@@ -0,0 +1,256 @@
+package channelserver
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Distribution item types the server applies itself, the client adds every other type on its own.
+const (
+	DistributionTypeItemBoxPage      uint8 = 30
+	DistributionTypeEquipmentBoxPage uint8 = 31
+)
+
+// Size of a single entry in response to MSG_MHF_ENUMERATE_DIST_ITEM, the name is followed by padding.
+const distributionEntrySize = 453
+
+var ErrDistributionUnavailable = errors.New("distribution is not available to this character")
+
+type Distribution struct {
+	ID              uint32       `db:"id"`
+	Name            string       `db:"name"`
+	Description     string       `db:"description"`
+	TimesAcceptable uint16       `db:"times_acceptable"`
+	TimesAccepted   uint16       `db:"times_accepted"`
+	StartTime       time.Time    `db:"start_time"`
+	Deadline        sql.NullTime `db:"deadline"`
+}
+
+type DistributionItem struct {
+	ItemType uint8  `db:"item_type"`
+	ItemID   uint16 `db:"item_id"`
+	Quantity uint16 `db:"quantity"`
+}
+
+// Selects distributions that are running and either untargeted or targeted at the character in $1.
+const distributionSelectQuery = `
+	SELECT d.id, d.name, d.description, d.times_acceptable, d.start_time, d.deadline,
+		(SELECT COUNT(*) FROM distributions_accepted da WHERE da.distribution_id = d.id AND da.character_id = $1) AS times_accepted
+	FROM distributions d
+	WHERE d.start_time <= now()
+		AND (d.deadline IS NULL OR d.deadline > now())
+		AND (
+			NOT EXISTS(SELECT 1 FROM distribution_targets dt WHERE dt.distribution_id = d.id)
+			OR EXISTS(SELECT 1 FROM distribution_targets dt WHERE dt.distribution_id = d.id AND dt.character_id = $1)
+		)
+`
+
+// GetDistributionsForCharacter returns all running distributions the character can see, claimed or not.
+func GetDistributionsForCharacter(s *Session, charID uint32) ([]*Distribution, error) {
+	distributions := make([]*Distribution, 0)
+
+	err := s.server.db.Select(&distributions, distributionSelectQuery+`
+		ORDER BY d.id
+	`, charID)
+
+	if err != nil {
+		s.logger.Error("failed to retrieve distributions", zap.Error(err), zap.Uint32("charID", charID))
+		return nil, err
+	}
+
+	return distributions, nil
+}
+
+// GetDistributionForCharacter returns nil when the distribution isn't available to the character.
+func GetDistributionForCharacter(s *Session, charID uint32, distributionID uint32) (*Distribution, error) {
+	distribution := &Distribution{}
+
+	err := s.server.db.QueryRowx(distributionSelectQuery+`
+		AND d.id = $2
+	`, charID, distributionID).StructScan(distribution)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		s.logger.Error(
+			"failed to retrieve distribution",
+			zap.Error(err),
+			zap.Uint32("charID", charID),
+			zap.Uint32("distributionID", distributionID),
+		)
+		return nil, err
+	}
+
+	return distribution, nil
+}
+
+func (d *Distribution) CanAccept() bool {
+	return d.TimesAccepted < d.TimesAcceptable
+}
+
+func (d *Distribution) Items(s *Session) ([]DistributionItem, error) {
+	items := make([]DistributionItem, 0)
+
+	err := s.server.db.Select(&items, `
+		SELECT item_type, item_id, quantity FROM distribution_items WHERE distribution_id = $1 ORDER BY id
+	`, d.ID)
+
+	if err != nil {
+		s.logger.Error("failed to retrieve distribution items", zap.Error(err), zap.Uint32("distributionID", d.ID))
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// Accept records a claim for the session's character, respecting the claim limit, and applies the items the server
+// is responsible for. Concurrent claims get the same claim number, so all but one of them are dropped by the unique constraint.
+func (d *Distribution) Accept(s *Session) error {
+	items, err := d.Items(s)
+
+	if err != nil {
+		return err
+	}
+
+	transaction, err := s.server.db.Begin()
+
+	if err != nil {
+		s.logger.Error("failed to start db transaction", zap.Error(err))
+		return err
+	}
+
+	result, err := transaction.Exec(`
+		INSERT INTO distributions_accepted (distribution_id, character_id, claim)
+		SELECT $1, $2, COUNT(*) + 1 FROM distributions_accepted WHERE distribution_id = $1 AND character_id = $2
+		HAVING COUNT(*) < $3
+		ON CONFLICT DO NOTHING
+	`, d.ID, s.charID, d.TimesAcceptable)
+
+	if err != nil {
+		s.logger.Error(
+			"failed to accept distribution",
+			zap.Error(err),
+			zap.Uint32("charID", s.charID),
+			zap.Uint32("distributionID", d.ID),
+		)
+		rollbackTransaction(s, transaction)
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+
+	if err != nil || affected == 0 {
+		rollbackTransaction(s, transaction)
+
+		if err != nil {
+			return err
+		}
+
+		return ErrDistributionUnavailable
+	}
+
+	for _, item := range items {
+		switch item.ItemType {
+		case DistributionTypeItemBoxPage:
+			err = unlockWarehousePages(s, transaction, s.charID, WarehouseBoxItems, item.Quantity)
+		case DistributionTypeEquipmentBoxPage:
+			err = unlockWarehousePages(s, transaction, s.charID, WarehouseBoxEquipment, item.Quantity)
+		}
+
+		if err != nil {
+			rollbackTransaction(s, transaction)
+			return err
+		}
+	}
+
+	err = transaction.Commit()
+
+	if err != nil {
+		s.logger.Error("failed to commit db transaction", zap.Error(err))
+		return err
+	}
+
+	d.TimesAccepted++
+
+	return nil
+}
+
+// DisplayDescription builds the text shown when the distribution is selected in the client.
+func (d *Distribution) DisplayDescription() string {
+	return fmt.Sprintf("~C05%s~C00\r\n%s", d.Name, d.Description)
+}
+
+// CreateCharacterDistribution creates a distribution only the given character can claim, once.
+func CreateCharacterDistribution(s *Session, charID uint32, name string, description string, items []DistributionItem) error {
+	transaction, err := s.server.db.Begin()
+
+	if err != nil {
+		s.logger.Error("failed to start db transaction", zap.Error(err))
+		return err
+	}
+
+	err = createCharacterDistribution(s, transaction, charID, name, description, items)
+
+	if err != nil {
+		rollbackTransaction(s, transaction)
+		return err
+	}
+
+	err = transaction.Commit()
+
+	if err != nil {
+		s.logger.Error("failed to commit db transaction", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// createCharacterDistribution creates the distribution as part of a larger transaction, the caller rolls back on error.
+func createCharacterDistribution(
+	s *Session,
+	transaction *sql.Tx,
+	charID uint32,
+	name string,
+	description string,
+	items []DistributionItem,
+) error {
+	var distributionID uint32
+
+	err := transaction.QueryRow(`
+		INSERT INTO distributions (name, description) VALUES ($1, $2) RETURNING id
+	`, name, description).Scan(&distributionID)
+
+	if err != nil {
+		s.logger.Error("failed to create distribution", zap.Error(err), zap.Uint32("charID", charID))
+		return err
+	}
+
+	_, err = transaction.Exec(`
+		INSERT INTO distribution_targets (distribution_id, character_id) VALUES ($1, $2)
+	`, distributionID, charID)
+
+	if err != nil {
+		s.logger.Error("failed to target distribution", zap.Error(err), zap.Uint32("charID", charID))
+		return err
+	}
+
+	for _, item := range items {
+		_, err = transaction.Exec(`
+			INSERT INTO distribution_items (distribution_id, item_type, item_id, quantity) VALUES ($1, $2, $3, $4)
+		`, distributionID, item.ItemType, item.ItemID, item.Quantity)
+
+		if err != nil {
+			s.logger.Error("failed to add distribution item", zap.Error(err), zap.Uint32("distributionID", distributionID))
+			return err
+		}
+	}
+
+	return nil
+}
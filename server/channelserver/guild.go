@@ -9,6 +9,7 @@ import (
 	"github.com/Andoryuuta/Erupe/common/stringsupport"
 	"github.com/jmoiron/sqlx"
 	"go.uber.org/zap"
+	"sort"
 	"time"
 )
 
@@ -26,6 +27,32 @@ var FestivalColourCodes = map[FestivalColour]uint8{
 	FestivalColourNone: 0xFF,
 }
 
+// RP needed to reach each guild rank after the first, rank 17 being the highest.
+var guildRankRP = []uint32{
+	24, 48, 96, 144, 192, 240, 288, 360, 432,
+	504, 600, 696, 792, 888, 984, 1080, 1200,
+}
+
+// Member caps, each unlocked once the guild reaches the given rank.
+var guildMemberLimits = []struct {
+	Rank  uint16
+	Limit uint16
+}{
+	{0, 30},
+	{3, 40},
+	{7, 50},
+	{10, 60},
+}
+
+var ErrGuildFull = errors.New("guild has reached its member limit")
+
+const (
+	// How long a leader can go without logging in before the guild is handed to another member.
+	GuildLeaderInactivityPeriod = 30 * 24 * time.Hour
+	// How often guilds are checked for inactive leaders.
+	guildLeaderCheckInterval = time.Hour
+)
+
 type GuildApplicationType string
 
 const (
@@ -45,8 +72,10 @@ type Guild struct {
 	FestivalColour FestivalColour `db:"festival_colour"`
 	GuildHallType  uint16         `db:"guild_hall"`
 	Icon           *GuildIcon     `db:"icon"`
+	Recruiting     bool           `db:"recruiting"`
 
 	GuildLeader
+	GuildPugi
 }
 
 type GuildLeader struct {
@@ -104,6 +133,14 @@ SELECT g.id,
        festival_colour,
 	   guild_hall,
        icon,
+       recruiting,
+       pugi_name_1,
+       pugi_name_2,
+       pugi_name_3,
+       pugi_outfit_1,
+       pugi_outfit_2,
+       pugi_outfit_3,
+       pugi_outfits,
        (
            SELECT count(1) FROM guild_characters gc WHERE gc.guild_id = g.id
        )             AS member_count
@@ -112,10 +149,43 @@ FROM guilds g
          JOIN characters lc on leader_id = lc.id
 `
 
+// Rank is derived from the RP the guild has accumulated and also decides which guild hall features are available.
+func (guild *Guild) Rank() uint16 {
+	for i, rp := range guildRankRP {
+		if guild.RP < rp {
+			return uint16(i)
+		}
+	}
+
+	return uint16(len(guildRankRP))
+}
+
+func (guild *Guild) IsMaxRank() bool {
+	return int(guild.Rank()) == len(guildRankRP)
+}
+
+func (guild *Guild) MemberLimit() uint16 {
+	rank := guild.Rank()
+	limit := guildMemberLimits[0].Limit
+
+	for _, l := range guildMemberLimits {
+		if rank >= l.Rank {
+			limit = l.Limit
+		}
+	}
+
+	return limit
+}
+
 func (guild *Guild) Save(s *Session) error {
 	_, err := s.server.db.Exec(`
-		UPDATE guilds SET main_motto=$1, sub_motto=$6, comment=$3, festival_colour=$4, icon=$5 WHERE id=$2
-	`, guild.MainMotto, guild.ID, guild.Comment, guild.FestivalColour, guild.Icon, guild.SubMotto)
+		UPDATE guilds SET main_motto=$1, sub_motto=$6, comment=$3, festival_colour=$4, icon=$5, recruiting=$7,
+			pugi_name_1=$8, pugi_name_2=$9, pugi_name_3=$10,
+			pugi_outfit_1=$11, pugi_outfit_2=$12, pugi_outfit_3=$13, pugi_outfits=$14
+		WHERE id=$2
+	`, guild.MainMotto, guild.ID, guild.Comment, guild.FestivalColour, guild.Icon, guild.SubMotto, guild.Recruiting,
+		guild.PugiName1, guild.PugiName2, guild.PugiName3,
+		guild.PugiOutfit1, guild.PugiOutfit2, guild.PugiOutfit3, guild.PugiOutfits)
 
 	if err != nil {
 		s.logger.Error("failed to update guild data", zap.Error(err), zap.Uint32("guildID", guild.ID))
@@ -206,7 +276,195 @@ func (guild *Guild) RemoveCharacter(s *Session, charID uint32) error {
 	return nil
 }
 
+// Successor returns the member next in line to lead the guild, nil if there is nobody.
+// Members avoiding leadership are only picked when allowAvoiding is set and nobody else is left.
+func (guild *Guild) Successor(s *Session, allowAvoiding bool) (*GuildMember, error) {
+	members, err := GetGuildMembers(s, guild.ID, false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return guildSuccessor(members, guild.LeaderCharID, allowAvoiding, time.Time{}), nil
+}
+
+// guildSuccessor picks the next leader out of the members in the way Successor does,
+// skipping members last seen before activeSince unless it is zero.
+func guildSuccessor(members []*GuildMember, leaderCharID uint32, allowAvoiding bool, activeSince time.Time) *GuildMember {
+	sorted := make([]*GuildMember, len(members))
+	copy(sorted, members)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].OrderIndex < sorted[j].OrderIndex
+	})
+
+	var fallback *GuildMember
+
+	for _, member := range sorted {
+		if member.CharID == leaderCharID || member.LastSeen().Before(activeSince) {
+			continue
+		}
+
+		if !member.AvoidLeadership {
+			return member
+		}
+
+		if fallback == nil && allowAvoiding {
+			fallback = member
+		}
+	}
+
+	return fallback
+}
+
+// TransferLeadership makes another member the leader, swapping their positions in the member list.
+func (guild *Guild) TransferLeadership(s *Session, charID uint32) error {
+	err := transferGuildLeadership(s.server.db, s.logger, guild.ID, guild.LeaderCharID, charID)
+
+	if err != nil {
+		return err
+	}
+
+	guild.LeaderCharID = charID
+
+	return nil
+}
+
+func transferGuildLeadership(db *sqlx.DB, logger *zap.Logger, guildID uint32, leaderCharID uint32, charID uint32) error {
+	transaction, err := db.Begin()
+
+	if err != nil {
+		logger.Error("failed to start db transaction", zap.Error(err))
+		return err
+	}
+
+	_, err = transaction.Exec(`
+		UPDATE guild_characters SET order_index = CASE character_id
+			WHEN $1 THEN (SELECT order_index FROM guild_characters WHERE character_id = $2)
+			ELSE (SELECT order_index FROM guild_characters WHERE character_id = $1)
+		END
+		WHERE character_id IN ($1, $2) AND guild_id = $3
+	`, leaderCharID, charID, guildID)
+
+	if err != nil {
+		logger.Error("failed to swap guild member order", zap.Error(err), zap.Uint32("guildID", guildID))
+		rollbackTransactionWithLogger(logger, transaction)
+		return err
+	}
+
+	result, err := transaction.Exec(`
+		UPDATE guilds SET leader_id = $1
+		WHERE id = $2 AND EXISTS(SELECT 1 FROM guild_characters WHERE character_id = $1 AND guild_id = $2)
+	`, charID, guildID)
+
+	if err != nil {
+		logger.Error(
+			"failed to transfer guild leadership",
+			zap.Error(err),
+			zap.Uint32("guildID", guildID),
+			zap.Uint32("charID", charID),
+		)
+		rollbackTransactionWithLogger(logger, transaction)
+		return err
+	}
+
+	transferred, err := result.RowsAffected()
+
+	if err != nil || transferred == 0 {
+		rollbackTransactionWithLogger(logger, transaction)
+		return fmt.Errorf("character '%d' is not a member of guild '%d'", charID, guildID)
+	}
+
+	err = transaction.Commit()
+
+	if err != nil {
+		logger.Error("failed to commit db transaction", zap.Error(err))
+		return err
+	}
+
+	logger.Info(
+		"Guild leadership transferred",
+		zap.Uint32("guildID", guildID),
+		zap.Uint32("from", leaderCharID),
+		zap.Uint32("to", charID),
+	)
+
+	return nil
+}
+
+type inactiveGuildLeader struct {
+	GuildID      uint32 `db:"id"`
+	LeaderCharID uint32 `db:"leader_id"`
+}
+
+// replaceInactiveGuildLeaders periodically hands guilds whose leader has gone inactive to the next active member.
+func (s *Server) replaceInactiveGuildLeaders() {
+	ticker := time.NewTicker(guildLeaderCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		s.Lock()
+		shutdown := s.isShuttingDown
+		s.Unlock()
+
+		if shutdown {
+			return
+		}
+
+		activeSince := time.Now().Add(-GuildLeaderInactivityPeriod)
+		leaders, err := s.getInactiveGuildLeaders(activeSince)
+
+		if err == nil {
+			for _, leader := range leaders {
+				_ = s.replaceInactiveGuildLeader(leader, activeSince)
+			}
+		}
+
+		<-ticker.C
+	}
+}
+
+// getInactiveGuildLeaders returns the leaders who haven't logged in or been active since activeSince.
+func (s *Server) getInactiveGuildLeaders(activeSince time.Time) ([]inactiveGuildLeader, error) {
+	leaders := make([]inactiveGuildLeader, 0)
+
+	err := s.db.Select(&leaders, `
+		SELECT g.id, g.leader_id FROM guilds g
+			JOIN characters c ON c.id = g.leader_id
+			LEFT JOIN guild_characters gc ON gc.character_id = g.leader_id
+		WHERE greatest(gc.last_active_at, to_timestamp(c.last_login)) < $1
+	`, activeSince)
+
+	if err != nil {
+		s.logger.Error("failed to retrieve guilds with inactive leaders", zap.Error(err))
+		return nil, err
+	}
+
+	return leaders, nil
+}
+
+// replaceInactiveGuildLeader leaves the guild unchanged when no other member has been active since activeSince.
+func (s *Server) replaceInactiveGuildLeader(leader inactiveGuildLeader, activeSince time.Time) error {
+	members, err := queryGuildMembers(s.db, s.logger, leader.GuildID, false)
+
+	if err != nil {
+		return err
+	}
+
+	successor := guildSuccessor(members, leader.LeaderCharID, false, activeSince)
+
+	if successor == nil {
+		return nil
+	}
+
+	return transferGuildLeadership(s.db, s.logger, leader.GuildID, leader.LeaderCharID, successor.CharID)
+}
+
 func (guild *Guild) AcceptApplication(s *Session, charID uint32) error {
+	if guild.MemberCount >= guild.MemberLimit() {
+		return ErrGuildFull
+	}
+
 	transaction, err := s.server.db.Begin()
 
 	if err != nil {
@@ -462,23 +720,62 @@ func CreateGuild(s *Session, guildName string) (int32, error) {
 }
 
 func rollbackTransaction(s *Session, transaction *sql.Tx) {
+	rollbackTransactionWithLogger(s.logger, transaction)
+}
+
+func rollbackTransactionWithLogger(logger *zap.Logger, transaction *sql.Tx) {
 	err := transaction.Rollback()
 
 	if err != nil {
-		s.logger.Error("failed to rollback transaction", zap.Error(err))
+		logger.Error("failed to rollback transaction", zap.Error(err))
 	}
 }
 
-func FindGuildsByName(s *Session, name string) ([]*Guild, error) {
-	searchTerm := fmt.Sprintf("%%%s%%", name)
+// Number of guilds returned per page of search results.
+const guildSearchPageSize = 10
+
+// Orders guild listings can be sorted by.
+const (
+	GuildOrderMembers = "member_count"
+	GuildOrderCreated = "g.created_at"
+	GuildOrderRP      = "g.rp"
+)
+
+func FindGuildsByName(s *Session, name string, page uint8) ([]*Guild, error) {
+	return findGuilds(s, "g.name ILIKE $1", "g.name", page, fmt.Sprintf("%%%s%%", name))
+}
+
+func FindGuildsByLeaderName(s *Session, name string, page uint8) ([]*Guild, error) {
+	return findGuilds(s, "lc.name ILIKE $1", "lc.name", page, fmt.Sprintf("%%%s%%", name))
+}
+
+func FindGuildsByLeaderID(s *Session, charID uint32) ([]*Guild, error) {
+	return findGuilds(s, "g.leader_id = $1", "g.id", 0, charID)
+}
+
+func FindGuildsByMotto(s *Session, mainMotto uint8, subMotto uint8, page uint8) ([]*Guild, error) {
+	return findGuilds(s, "g.main_motto = $1 AND g.sub_motto = $2", "g.id", page, mainMotto, subMotto)
+}
+
+// ListGuilds lists every guild, or only those accepting applications, in the given order.
+func ListGuilds(s *Session, order string, descending bool, recruitingOnly bool, page uint8) ([]*Guild, error) {
+	if descending {
+		order += " DESC"
+	}
+
+	return findGuilds(s, "(g.recruiting OR NOT $1)", order+", g.id", page, recruitingOnly)
+}
 
+func findGuilds(s *Session, condition string, order string, page uint8, args ...interface{}) ([]*Guild, error) {
 	rows, err := s.server.db.Queryx(fmt.Sprintf(`
 		%s
-		WHERE g.name ILIKE $1
-	`, guildInfoSelectQuery), searchTerm)
+		WHERE %s
+		ORDER BY %s
+		LIMIT %d OFFSET %d
+	`, guildInfoSelectQuery, condition, order, guildSearchPageSize, int(page)*guildSearchPageSize), args...)
 
 	if err != nil {
-		s.logger.Error("failed to find guilds for search term", zap.Error(err), zap.String("searchTerm", name))
+		s.logger.Error("failed to search guilds", zap.Error(err))
 		return nil, err
 	}
 
@@ -0,0 +1,144 @@
+package channelserver
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// How long an adventure is away before its rewards can be acquired.
+	GuildAdventureDuration = 6 * time.Hour
+	// How long returned adventures stay listed.
+	guildAdventureRetention = 7 * 24 * time.Hour
+)
+
+var (
+	ErrGuildAdventureNotReturned = errors.New("adventure has not returned yet")
+	ErrGuildAdventureCollected   = errors.New("adventure rewards have already been acquired")
+)
+
+type GuildAdventure struct {
+	ID          uint32    `db:"id"`
+	GuildID     uint32    `db:"guild_id"`
+	Destination uint32    `db:"destination"`
+	Charge      uint32    `db:"charge"`
+	Depart      time.Time `db:"departed_at"`
+	Return      time.Time `db:"returns_at"`
+
+	// Whether the character the adventure was retrieved for has acquired its rewards.
+	Collected bool `db:"collected"`
+}
+
+// Selects adventures with the character in $1 as the viewer.
+const guildAdventureSelectSQL = `
+	SELECT id, guild_id, destination, charge, departed_at, returns_at,
+		EXISTS(SELECT 1 FROM guild_adventure_claims gac WHERE gac.adventure_id = ga.id AND gac.character_id = $1) AS collected
+	FROM guild_adventures ga
+`
+
+func (guild *Guild) Adventures(s *Session, charID uint32) ([]*GuildAdventure, error) {
+	adventures := make([]*GuildAdventure, 0)
+
+	err := s.server.db.Select(&adventures, guildAdventureSelectSQL+`
+		WHERE guild_id = $2 AND returns_at > $3
+		ORDER BY departed_at
+	`, charID, guild.ID, time.Now().Add(-guildAdventureRetention))
+
+	if err != nil {
+		s.logger.Error("failed to retrieve guild adventures", zap.Error(err), zap.Uint32("guildID", guild.ID))
+		return nil, err
+	}
+
+	return adventures, nil
+}
+
+// GetAdventure returns nil when the adventure doesn't belong to the guild.
+func (guild *Guild) GetAdventure(s *Session, charID uint32, adventureID uint32) (*GuildAdventure, error) {
+	adventure := &GuildAdventure{}
+
+	err := s.server.db.QueryRowx(guildAdventureSelectSQL+`
+		WHERE guild_id = $2 AND id = $3
+	`, charID, guild.ID, adventureID).StructScan(adventure)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		s.logger.Error("failed to retrieve guild adventure", zap.Error(err), zap.Uint32("adventureID", adventureID))
+		return nil, err
+	}
+
+	return adventure, nil
+}
+
+func (guild *Guild) RegisterAdventure(s *Session, destination uint32) error {
+	now := time.Now()
+
+	_, err := s.server.db.Exec(`
+		INSERT INTO guild_adventures (guild_id, destination, departed_at, returns_at) VALUES ($1, $2, $3, $4)
+	`, guild.ID, destination, now, now.Add(GuildAdventureDuration))
+
+	if err != nil {
+		s.logger.Error("failed to register guild adventure", zap.Error(err), zap.Uint32("guildID", guild.ID))
+		return err
+	}
+
+	return nil
+}
+
+func (a *GuildAdventure) AddCharge(s *Session, amount uint32) error {
+	_, err := s.server.db.Exec("UPDATE guild_adventures SET charge = charge + $1 WHERE id = $2", amount, a.ID)
+
+	if err != nil {
+		s.logger.Error("failed to charge guild adventure", zap.Error(err), zap.Uint32("adventureID", a.ID))
+		return err
+	}
+
+	a.Charge += amount
+
+	return nil
+}
+
+// CanAcquire checks the adventure has returned by now and its rewards haven't been acquired yet.
+func (a *GuildAdventure) CanAcquire(now time.Time) error {
+	if now.Before(a.Return) {
+		return ErrGuildAdventureNotReturned
+	}
+
+	if a.Collected {
+		return ErrGuildAdventureCollected
+	}
+
+	return nil
+}
+
+// Acquire records that the character collected the rewards of a returned adventure.
+func (a *GuildAdventure) Acquire(s *Session, charID uint32) error {
+	err := a.CanAcquire(time.Now())
+
+	if err != nil {
+		return err
+	}
+
+	_, err = s.server.db.Exec(`
+		INSERT INTO guild_adventure_claims (adventure_id, character_id) VALUES ($1, $2)
+	`, a.ID, charID)
+
+	if err != nil {
+		s.logger.Error(
+			"failed to acquire guild adventure",
+			zap.Error(err),
+			zap.Uint32("adventureID", a.ID),
+			zap.Uint32("charID", charID),
+		)
+		return err
+	}
+
+	a.Collected = true
+
+	return nil
+}
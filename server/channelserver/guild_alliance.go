@@ -0,0 +1,309 @@
+package channelserver
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Number of guilds the client can show in an alliance, including the parent guild.
+const GuildAllianceMaxGuilds = 3
+
+var (
+	ErrGuildAllianceFull         = errors.New("alliance is full")
+	ErrGuildAllianceNoInvitation = errors.New("guild has not been invited to the alliance")
+)
+
+type GuildAlliance struct {
+	ID            uint32    `db:"id"`
+	Name          string    `db:"name"`
+	CreatedAt     time.Time `db:"created_at"`
+	ParentGuildID uint32    `db:"parent_id"`
+
+	// Member guilds, the parent guild first.
+	Guilds []*Guild
+}
+
+func GetAllianceByID(s *Session, allianceID uint32) (*GuildAlliance, error) {
+	return getAlliance(s, `SELECT id, name, created_at, parent_id FROM guild_alliances WHERE id = $1`, allianceID)
+}
+
+func GetAllianceByGuildID(s *Session, guildID uint32) (*GuildAlliance, error) {
+	return getAlliance(s, `
+		SELECT ga.id, ga.name, ga.created_at, ga.parent_id
+		FROM guild_alliances ga
+			JOIN guild_alliance_members gam ON gam.alliance_id = ga.id
+		WHERE gam.guild_id = $1
+	`, guildID)
+}
+
+func getAlliance(s *Session, query string, args ...interface{}) (*GuildAlliance, error) {
+	alliance := &GuildAlliance{}
+
+	err := s.server.db.QueryRowx(query, args...).StructScan(alliance)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		s.logger.Error("failed to retrieve alliance", zap.Error(err))
+		return nil, err
+	}
+
+	var guildIDs []uint32
+
+	err = s.server.db.Select(&guildIDs, `
+		SELECT guild_id FROM guild_alliance_members WHERE alliance_id = $1
+		ORDER BY guild_id = $2 DESC, joined_at
+	`, alliance.ID, alliance.ParentGuildID)
+
+	if err != nil {
+		s.logger.Error("failed to retrieve alliance members", zap.Error(err), zap.Uint32("allianceID", alliance.ID))
+		return nil, err
+	}
+
+	for _, guildID := range guildIDs {
+		guild, err := GetGuildInfoByID(s, guildID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if guild != nil {
+			alliance.Guilds = append(alliance.Guilds, guild)
+		}
+	}
+
+	return alliance, nil
+}
+
+// CreateAlliance creates an alliance with the given guild as its parent.
+func CreateAlliance(s *Session, parentGuildID uint32, name string) (uint32, error) {
+	transaction, err := s.server.db.Begin()
+
+	if err != nil {
+		s.logger.Error("failed to start db transaction", zap.Error(err))
+		return 0, err
+	}
+
+	var allianceID uint32
+
+	err = transaction.QueryRow(`
+		INSERT INTO guild_alliances (name, parent_id) VALUES ($1, $2) RETURNING id
+	`, name, parentGuildID).Scan(&allianceID)
+
+	if err != nil {
+		s.logger.Error("failed to create alliance", zap.Error(err), zap.Uint32("guildID", parentGuildID))
+		rollbackTransaction(s, transaction)
+		return 0, err
+	}
+
+	_, err = transaction.Exec(`
+		INSERT INTO guild_alliance_members (alliance_id, guild_id) VALUES ($1, $2)
+	`, allianceID, parentGuildID)
+
+	if err != nil {
+		s.logger.Error("failed to add alliance parent guild", zap.Error(err), zap.Uint32("guildID", parentGuildID))
+		rollbackTransaction(s, transaction)
+		return 0, err
+	}
+
+	err = transaction.Commit()
+
+	if err != nil {
+		s.logger.Error("failed to commit db transaction", zap.Error(err))
+		return 0, err
+	}
+
+	return allianceID, nil
+}
+
+func (a *GuildAlliance) IsFull() bool {
+	return len(a.Guilds) >= GuildAllianceMaxGuilds
+}
+
+func (a *GuildAlliance) HasGuild(guildID uint32) bool {
+	for _, guild := range a.Guilds {
+		if guild.ID == guildID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (a *GuildAlliance) Invite(s *Session, guildID uint32) error {
+	if a.IsFull() {
+		return ErrGuildAllianceFull
+	}
+
+	if a.HasGuild(guildID) {
+		return fmt.Errorf("guild %d is already in alliance %d", guildID, a.ID)
+	}
+
+	_, err := s.server.db.Exec(`
+		INSERT INTO guild_alliance_invitations (alliance_id, guild_id) VALUES ($1, $2) ON CONFLICT DO NOTHING
+	`, a.ID, guildID)
+
+	if err != nil {
+		s.logger.Error(
+			"failed to invite guild to alliance",
+			zap.Error(err),
+			zap.Uint32("allianceID", a.ID),
+			zap.Uint32("guildID", guildID),
+		)
+		return err
+	}
+
+	return nil
+}
+
+// AcceptInvitation adds an invited guild to the alliance, dropping any other invitations it holds.
+func (a *GuildAlliance) AcceptInvitation(s *Session, guildID uint32) error {
+	transaction, err := s.server.db.Begin()
+
+	if err != nil {
+		s.logger.Error("failed to start db transaction", zap.Error(err))
+		return err
+	}
+
+	// Locked so guilds accepting at the same time are counted one after the other
+	var allianceID uint32
+
+	err = transaction.QueryRow("SELECT id FROM guild_alliances WHERE id = $1 FOR UPDATE", a.ID).Scan(&allianceID)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		rollbackTransaction(s, transaction)
+		return ErrGuildAllianceNoInvitation
+	}
+
+	if err != nil {
+		s.logger.Error("failed to lock alliance", zap.Error(err), zap.Uint32("allianceID", a.ID))
+		rollbackTransaction(s, transaction)
+		return err
+	}
+
+	result, err := transaction.Exec(`
+		DELETE FROM guild_alliance_invitations WHERE alliance_id = $1 AND guild_id = $2
+	`, a.ID, guildID)
+
+	if err != nil {
+		rollbackTransaction(s, transaction)
+		return err
+	}
+
+	invited, err := result.RowsAffected()
+
+	if err != nil {
+		rollbackTransaction(s, transaction)
+		return err
+	}
+
+	if invited == 0 {
+		rollbackTransaction(s, transaction)
+		return ErrGuildAllianceNoInvitation
+	}
+
+	_, err = transaction.Exec("DELETE FROM guild_alliance_invitations WHERE guild_id = $1", guildID)
+
+	if err != nil {
+		rollbackTransaction(s, transaction)
+		return err
+	}
+
+	var members int
+
+	err = transaction.QueryRow(`
+		SELECT COUNT(*) FROM guild_alliance_members WHERE alliance_id = $1
+	`, a.ID).Scan(&members)
+
+	if err != nil {
+		rollbackTransaction(s, transaction)
+		return err
+	}
+
+	if members >= GuildAllianceMaxGuilds {
+		rollbackTransaction(s, transaction)
+		return ErrGuildAllianceFull
+	}
+
+	_, err = transaction.Exec(`
+		INSERT INTO guild_alliance_members (alliance_id, guild_id) VALUES ($1, $2)
+	`, a.ID, guildID)
+
+	if err != nil {
+		s.logger.Error(
+			"failed to add guild to alliance",
+			zap.Error(err),
+			zap.Uint32("allianceID", a.ID),
+			zap.Uint32("guildID", guildID),
+		)
+		rollbackTransaction(s, transaction)
+		return err
+	}
+
+	err = transaction.Commit()
+
+	if err != nil {
+		s.logger.Error("failed to commit db transaction", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (a *GuildAlliance) DeclineInvitation(s *Session, guildID uint32) error {
+	_, err := s.server.db.Exec(`
+		DELETE FROM guild_alliance_invitations WHERE alliance_id = $1 AND guild_id = $2
+	`, a.ID, guildID)
+
+	if err != nil {
+		s.logger.Error(
+			"failed to decline alliance invitation",
+			zap.Error(err),
+			zap.Uint32("allianceID", a.ID),
+			zap.Uint32("guildID", guildID),
+		)
+		return err
+	}
+
+	return nil
+}
+
+// RemoveGuild takes a guild other than the parent out of the alliance, for both leaving and kicking.
+func (a *GuildAlliance) RemoveGuild(s *Session, guildID uint32) error {
+	if guildID == a.ParentGuildID {
+		return fmt.Errorf("parent guild %d cannot leave alliance %d", guildID, a.ID)
+	}
+
+	_, err := s.server.db.Exec(`
+		DELETE FROM guild_alliance_members WHERE alliance_id = $1 AND guild_id = $2
+	`, a.ID, guildID)
+
+	if err != nil {
+		s.logger.Error(
+			"failed to remove guild from alliance",
+			zap.Error(err),
+			zap.Uint32("allianceID", a.ID),
+			zap.Uint32("guildID", guildID),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (a *GuildAlliance) Disband(s *Session) error {
+	_, err := s.server.db.Exec("DELETE FROM guild_alliances WHERE id = $1", a.ID)
+
+	if err != nil {
+		s.logger.Error("failed to disband alliance", zap.Error(err), zap.Uint32("allianceID", a.ID))
+		return err
+	}
+
+	return nil
+}
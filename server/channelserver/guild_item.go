@@ -0,0 +1,171 @@
+package channelserver
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Andoryuuta/Erupe/network/mhfpacket"
+	"go.uber.org/zap"
+)
+
+// Number of stacks the guild item box holds.
+const GuildItemBoxCapacity = 100
+
+var ErrGuildItemsChanged = errors.New("guild item box changed since it was last listed")
+
+func (guild *Guild) Items(s *Session) ([]*mhfpacket.WarehouseItem, error) {
+	rows, err := s.server.db.Query(`
+		SELECT id, item_id, quantity FROM guild_items WHERE guild_id = $1 ORDER BY id
+	`, guild.ID)
+
+	if err != nil {
+		s.logger.Error("failed to retrieve guild items", zap.Error(err), zap.Uint32("guildID", guild.ID))
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	items := make([]*mhfpacket.WarehouseItem, 0)
+
+	for rows.Next() {
+		item := &mhfpacket.WarehouseItem{}
+
+		err = rows.Scan(&item.WarehouseID, &item.ItemID, &item.Quantity)
+
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// UpdateItems applies the stacks changed by a member, logging the amount each stack moved by.
+// The client sends absolute quantities, so each one is applied as a change from the quantity in seen,
+// the stacks as last listed to the member, keeping other members' changes made in the meantime.
+func (guild *Guild) UpdateItems(s *Session, charID uint32, seen map[uint32]uint16, changes []*mhfpacket.WarehouseItem) error {
+	transaction, err := s.server.db.Begin()
+
+	if err != nil {
+		s.logger.Error("failed to start db transaction", zap.Error(err))
+		return err
+	}
+
+	for _, change := range changes {
+		err = guild.updateItem(s, transaction, charID, seen[change.WarehouseID], change)
+
+		if err != nil {
+			s.logger.Error(
+				"failed to update guild item",
+				zap.Error(err),
+				zap.Uint32("guildID", guild.ID),
+				zap.Uint32("charID", charID),
+				zap.Uint16("itemID", change.ItemID),
+			)
+			rollbackTransaction(s, transaction)
+			return err
+		}
+	}
+
+	var count int
+
+	err = transaction.QueryRow("SELECT COUNT(*) FROM guild_items WHERE guild_id = $1", guild.ID).Scan(&count)
+
+	if err != nil {
+		rollbackTransaction(s, transaction)
+		return err
+	}
+
+	if count > GuildItemBoxCapacity {
+		rollbackTransaction(s, transaction)
+		return fmt.Errorf("%d stacks exceed the guild item box capacity", count)
+	}
+
+	err = transaction.Commit()
+
+	if err != nil {
+		s.logger.Error("failed to commit db transaction", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// guildItemQuantity returns the quantity of a stack holding current items after a member who last saw seen
+// items sets it to sent.
+func guildItemQuantity(current, seen, sent uint16) (uint16, error) {
+	if sent > WarehouseMaxStackSize {
+		return 0, fmt.Errorf("stack of %d exceeds the maximum stack size", sent)
+	}
+
+	quantity := int(current) + int(sent) - int(seen)
+
+	if quantity < 0 {
+		return 0, ErrGuildItemsChanged
+	}
+
+	if quantity > WarehouseMaxStackSize {
+		return 0, fmt.Errorf("stack of %d exceeds the maximum stack size", quantity)
+	}
+
+	return uint16(quantity), nil
+}
+
+func (guild *Guild) updateItem(s *Session, transaction *sql.Tx, charID uint32, seen uint16, change *mhfpacket.WarehouseItem) error {
+	var itemID, current uint16
+
+	err := transaction.QueryRow(`
+		SELECT item_id, quantity FROM guild_items WHERE id = $1 AND guild_id = $2 FOR UPDATE
+	`, change.WarehouseID, guild.ID).Scan(&itemID, &current)
+
+	exists := true
+
+	if errors.Is(err, sql.ErrNoRows) {
+		exists = false
+		itemID = change.ItemID
+	} else if err != nil {
+		return err
+	}
+
+	if exists && itemID != change.ItemID {
+		return fmt.Errorf("stack %d holds item %d, not %d", change.WarehouseID, itemID, change.ItemID)
+	}
+
+	quantity, err := guildItemQuantity(current, seen, change.Quantity)
+
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case !exists && quantity == 0:
+		return nil
+	case !exists:
+		_, err = transaction.Exec(`
+			INSERT INTO guild_items (guild_id, item_id, quantity) VALUES ($1, $2, $3)
+		`, guild.ID, change.ItemID, quantity)
+	case quantity == 0:
+		_, err = transaction.Exec("DELETE FROM guild_items WHERE id = $1", change.WarehouseID)
+	default:
+		_, err = transaction.Exec("UPDATE guild_items SET quantity = $1 WHERE id = $2", quantity, change.WarehouseID)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	delta := int(quantity) - int(current)
+
+	if delta == 0 {
+		return nil
+	}
+
+	_, err = transaction.Exec(`
+		INSERT INTO guild_item_logs (guild_id, character_id, item_id, quantity) VALUES ($1, $2, $3, $4)
+	`, guild.ID, charID, itemID, delta)
+
+	return err
+}
@@ -0,0 +1,33 @@
+package channelserver
+
+import (
+	"testing"
+)
+
+func TestGuildItemQuantity(t *testing.T) {
+	tests := []struct {
+		name    string
+		current uint16
+		seen    uint16
+		sent    uint16
+		want    uint16
+		wantErr bool
+	}{
+		{"deposit into new stack", 0, 0, 5, 5, false},
+		{"withdraw", 10, 10, 7, 7, false},
+		{"withdraw after another member withdrew", 7, 10, 5, 2, false},
+		{"deposit after another member withdrew", 7, 10, 12, 9, false},
+		{"withdraw more than is left", 2, 10, 5, 0, true},
+		{"withdraw from a stack another member emptied", 0, 10, 0, 0, true},
+		{"exceed the stack size", WarehouseMaxStackSize, 0, 1, 0, true},
+		{"send more than the stack size", 0, 0, WarehouseMaxStackSize + 1, 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := guildItemQuantity(tt.current, tt.seen, tt.sent)
+
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("%s: got %d, %v, want %d, error %v", tt.name, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
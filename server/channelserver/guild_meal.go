@@ -0,0 +1,77 @@
+package channelserver
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// How long a guild meal stays active after it is cooked.
+const GuildMealDuration = time.Hour
+
+type GuildMeal struct {
+	ID        uint32    `db:"id"`
+	MealID    uint16    `db:"meal_id"`
+	Level     uint8     `db:"level"`
+	CreatorID uint32    `db:"creator_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (m *GuildMeal) ExpiresAt() time.Time {
+	return m.CreatedAt.Add(GuildMealDuration)
+}
+
+// guildMealsCookedAfter returns the time meals must have been cooked after to still be active at now.
+func guildMealsCookedAfter(now time.Time) time.Time {
+	return now.Add(-GuildMealDuration)
+}
+
+// Meals returns the guild's meals that are still active.
+func (guild *Guild) Meals(s *Session) ([]*GuildMeal, error) {
+	meals := make([]*GuildMeal, 0)
+
+	err := s.server.db.Select(&meals, `
+		SELECT id, meal_id, level, creator_id, created_at FROM guild_meals
+		WHERE guild_id = $1 AND created_at > $2
+		ORDER BY created_at
+	`, guild.ID, guildMealsCookedAfter(time.Now()))
+
+	if err != nil {
+		s.logger.Error("failed to retrieve guild meals", zap.Error(err), zap.Uint32("guildID", guild.ID))
+		return nil, err
+	}
+
+	return meals, nil
+}
+
+// CookMeal stores a meal cooked by a member, replacing the guild's meal with the given ID when it isn't 0.
+func (guild *Guild) CookMeal(s *Session, charID uint32, overwriteID uint32, mealID uint16, level uint8) (*GuildMeal, error) {
+	meal := &GuildMeal{}
+
+	var err error
+
+	if overwriteID != 0 {
+		err = s.server.db.QueryRowx(`
+			UPDATE guild_meals SET meal_id = $1, level = $2, creator_id = $3, created_at = now()
+			WHERE id = $4 AND guild_id = $5
+			RETURNING id, meal_id, level, creator_id, created_at
+		`, mealID, level, charID, overwriteID, guild.ID).StructScan(meal)
+	} else {
+		err = s.server.db.QueryRowx(`
+			INSERT INTO guild_meals (guild_id, meal_id, level, creator_id) VALUES ($1, $2, $3, $4)
+			RETURNING id, meal_id, level, creator_id, created_at
+		`, guild.ID, mealID, level, charID).StructScan(meal)
+	}
+
+	if err != nil {
+		s.logger.Error(
+			"failed to save guild meal",
+			zap.Error(err),
+			zap.Uint32("guildID", guild.ID),
+			zap.Uint32("charID", charID),
+		)
+		return nil, err
+	}
+
+	return meal, nil
+}
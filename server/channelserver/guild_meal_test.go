@@ -0,0 +1,56 @@
+package channelserver
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGuildMealsCookedAfter(t *testing.T) {
+	now := time.Date(2020, 3, 1, 12, 30, 0, 0, time.UTC)
+	cutoff := guildMealsCookedAfter(now)
+
+	tests := []struct {
+		name   string
+		cooked time.Time
+		active bool
+	}{
+		{"just cooked", now, true},
+		{"about to expire", now.Add(-GuildMealDuration + time.Second), true},
+		{"expiring now", now.Add(-GuildMealDuration), false},
+		{"expired", now.Add(-GuildMealDuration - time.Minute), false},
+	}
+
+	for _, tt := range tests {
+		meal := &GuildMeal{CreatedAt: tt.cooked}
+
+		// Meals selects the ones cooked after the cutoff, which must be the ones yet to expire
+		if got := tt.cooked.After(cutoff); got != tt.active {
+			t.Errorf("%s: cooked after the cutoff = %v, want %v", tt.name, got, tt.active)
+		}
+
+		if got := meal.ExpiresAt().After(now); got != tt.active {
+			t.Errorf("%s: expires after now = %v, want %v", tt.name, got, tt.active)
+		}
+	}
+}
+
+func TestGuildAdventureCanAcquire(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		adventure GuildAdventure
+		want      error
+	}{
+		{"away", GuildAdventure{Return: now.Add(time.Minute)}, ErrGuildAdventureNotReturned},
+		{"returning now", GuildAdventure{Return: now}, nil},
+		{"returned", GuildAdventure{Return: now.Add(-time.Minute)}, nil},
+		{"acquired", GuildAdventure{Return: now.Add(-time.Minute), Collected: true}, ErrGuildAdventureCollected},
+	}
+
+	for _, tt := range tests {
+		if got := tt.adventure.CanAcquire(now); got != tt.want {
+			t.Errorf("%s adventure: CanAcquire() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -15,11 +15,24 @@ type GuildMember struct {
 	IsApplicant     bool       `db:"is_applicant"`
 	OrderIndex      uint8      `db:"order_index"`
 	LastLogin       uint32     `db:"last_login"`
+	LastActive      uint32     `db:"last_active"`
 	AvoidLeadership bool       `db:"avoid_leadership"`
 	IsLeader        bool       `db:"is_leader"`
+	Recruiter       bool       `db:"recruiter"`
 	Exp             uint16     `db:"exp"`
 }
 
+// LastSeen returns when the member last logged in or was active in the guild.
+func (gm *GuildMember) LastSeen() time.Time {
+	seen := gm.LastActive
+
+	if gm.LastLogin > seen {
+		seen = gm.LastLogin
+	}
+
+	return time.Unix(int64(seen), 0)
+}
+
 func (gm *GuildMember) IsSubLeader() bool {
 	return gm.OrderIndex <= 3 && !gm.AvoidLeadership
 }
@@ -44,9 +57,28 @@ func (gm *GuildMember) Save(s *Session) error {
 	return nil
 }
 
-//TODO add the recruiter permission to this check when it exists
 func (gm *GuildMember) IsRecruiter() bool {
-	return gm.IsLeader || gm.IsSubLeader()
+	return gm.IsLeader || gm.IsSubLeader() || gm.Recruiter
+}
+
+func (gm *GuildMember) SetRecruiter(s *Session, recruiter bool) error {
+	_, err := s.server.db.Exec(`
+		UPDATE guild_characters SET recruiter = $1 WHERE character_id = $2
+	`, recruiter, gm.CharID)
+
+	if err != nil {
+		s.logger.Error(
+			"failed to update guild member rights",
+			zap.Error(err),
+			zap.Uint32("charID", gm.CharID),
+			zap.Uint32("guildID", gm.GuildID),
+		)
+		return err
+	}
+
+	gm.Recruiter = recruiter
+
+	return nil
 }
 
 const guildMembersSelectSQL = `
@@ -56,7 +88,9 @@ SELECT g.id                                           as guild_id,
        character.character_id,
        coalesce(gc.order_index, 0) as order_index,
        c.last_login,
+       coalesce(extract(epoch FROM gc.last_active_at)::int, c.last_login, 0) as last_active,
        coalesce(gc.avoid_leadership, false) as avoid_leadership,
+       coalesce(gc.recruiter, false) as recruiter,
        c.exp,
        character.is_applicant,
        CASE WHEN g.leader_id = c.id THEN 1 ELSE 0 END as is_leader
@@ -74,13 +108,18 @@ FROM (
 `
 
 func GetGuildMembers(s *Session, guildID uint32, applicants bool) ([]*GuildMember, error) {
-	rows, err := s.server.db.Queryx(fmt.Sprintf(`
+	return queryGuildMembers(s.server.db, s.logger, guildID, applicants)
+}
+
+// queryGuildMembers works like GetGuildMembers for server jobs running outside of a session.
+func queryGuildMembers(db *sqlx.DB, logger *zap.Logger, guildID uint32, applicants bool) ([]*GuildMember, error) {
+	rows, err := db.Queryx(fmt.Sprintf(`
 			%s
 			WHERE character.guild_id = $1 AND is_applicant = $2
 	`, guildMembersSelectSQL), guildID, applicants)
 
 	if err != nil {
-		s.logger.Error("failed to retrieve membership data for guild", zap.Error(err), zap.Uint32("guildID", guildID))
+		logger.Error("failed to retrieve membership data for guild", zap.Error(err), zap.Uint32("guildID", guildID))
 		return nil, err
 	}
 
@@ -89,9 +128,12 @@ func GetGuildMembers(s *Session, guildID uint32, applicants bool) ([]*GuildMembe
 	members := make([]*GuildMember, 0)
 
 	for rows.Next() {
-		member, err := buildGuildMemberObjectFromDBResult(rows, err, s)
+		member := &GuildMember{}
+
+		err = rows.StructScan(member)
 
 		if err != nil {
+			logger.Error("failed to retrieve guild data from database", zap.Error(err))
 			return nil, err
 		}
 
@@ -0,0 +1,221 @@
+package channelserver
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Andoryuuta/byteframe"
+	"go.uber.org/zap"
+)
+
+const (
+	// Number of missions the client reads from the mission list and the guild's record.
+	guildMissionSlots = 16
+	// Size of a single mission entry in both responses.
+	guildMissionEntrySize = 25
+)
+
+var (
+	ErrGuildMissionNotTargeted = errors.New("mission is not targeted by the guild")
+	ErrGuildMissionCompleted   = errors.New("mission was already completed this week")
+)
+
+type GuildMission struct {
+	ID         uint32 `db:"id"`
+	TextID     uint32 `db:"text_id"`
+	TargetID   uint32 `db:"target_id"`
+	Quantity   uint16 `db:"quantity"`
+	Difficulty uint8  `db:"difficulty"`
+	Category   uint8  `db:"category"`
+	RewardRP   uint16 `db:"reward_rp"`
+
+	// Only set for missions targeted by a guild, the list uses the current time instead.
+	Progress uint32    `db:"progress"`
+	SetAt    time.Time `db:"set_at"`
+}
+
+func GetGuildMissions(s *Session) ([]*GuildMission, error) {
+	missions := make([]*GuildMission, 0)
+
+	err := s.server.db.Select(&missions, `
+		SELECT id, text_id, target_id, quantity, difficulty, category, reward_rp, now() AS set_at FROM guild_missions
+		ORDER BY id LIMIT $1
+	`, guildMissionSlots)
+
+	if err != nil {
+		s.logger.Error("failed to retrieve guild missions", zap.Error(err))
+		return nil, err
+	}
+
+	return missions, nil
+}
+
+// Missions returns the missions the guild is currently working on.
+func (guild *Guild) Missions(s *Session) ([]*GuildMission, error) {
+	missions := make([]*GuildMission, 0)
+
+	err := s.server.db.Select(&missions, `
+		SELECT gm.id, gm.text_id, gm.target_id, gm.quantity, gm.difficulty, gm.category, gm.reward_rp,
+			gmt.progress, gmt.set_at
+		FROM guild_mission_targets gmt
+			JOIN guild_missions gm ON gm.id = gmt.mission_id
+		WHERE gmt.guild_id = $1 AND gmt.completed_at IS NULL
+		ORDER BY gmt.set_at
+	`, guild.ID)
+
+	if err != nil {
+		s.logger.Error("failed to retrieve guild mission targets", zap.Error(err), zap.Uint32("guildID", guild.ID))
+		return nil, err
+	}
+
+	return missions, nil
+}
+
+// SetMissionTarget starts a mission for the guild. Completed missions can only be restarted once the guild week
+// they were completed in has reset, so their RP can't be collected again straight away.
+func (guild *Guild) SetMissionTarget(s *Session, missionID uint32) error {
+	missions, err := guild.Missions(s)
+
+	if err != nil {
+		return err
+	}
+
+	if len(missions) >= guildMissionSlots {
+		return fmt.Errorf("guild already has %d mission targets", len(missions))
+	}
+
+	result, err := s.server.db.Exec(`
+		INSERT INTO guild_mission_targets (guild_id, mission_id) VALUES ($1, $2)
+		ON CONFLICT (guild_id, mission_id) DO UPDATE SET progress = 0, set_at = now(), completed_at = NULL
+			WHERE guild_mission_targets.completed_at IS NULL OR guild_mission_targets.completed_at < $3
+	`, guild.ID, missionID, guildWeekStart(time.Now()))
+
+	if err != nil {
+		s.logger.Error(
+			"failed to set guild mission target",
+			zap.Error(err),
+			zap.Uint32("guildID", guild.ID),
+			zap.Uint32("missionID", missionID),
+		)
+		return err
+	}
+
+	set, err := result.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if set == 0 {
+		return ErrGuildMissionCompleted
+	}
+
+	return nil
+}
+
+func (guild *Guild) CancelMissionTarget(s *Session, missionID uint32) error {
+	_, err := s.server.db.Exec(`
+		DELETE FROM guild_mission_targets WHERE guild_id = $1 AND mission_id = $2 AND completed_at IS NULL
+	`, guild.ID, missionID)
+
+	if err != nil {
+		s.logger.Error(
+			"failed to cancel guild mission target",
+			zap.Error(err),
+			zap.Uint32("guildID", guild.ID),
+			zap.Uint32("missionID", missionID),
+		)
+		return err
+	}
+
+	return nil
+}
+
+// AddMissionCount adds progress to a targeted mission, completing it and paying out its RP once the quantity is reached.
+// Progress is capped at the mission's quantity.
+func (guild *Guild) AddMissionCount(s *Session, missionID uint32, count uint32) error {
+	transaction, err := s.server.db.Begin()
+
+	if err != nil {
+		s.logger.Error("failed to start db transaction", zap.Error(err))
+		return err
+	}
+
+	var progress uint32
+	var quantity, rewardRP uint16
+
+	err = transaction.QueryRow(`
+		UPDATE guild_mission_targets gmt SET progress = LEAST(gmt.progress + LEAST($3::bigint, gm.quantity), gm.quantity)
+		FROM guild_missions gm
+		WHERE gm.id = gmt.mission_id AND gmt.guild_id = $1 AND gmt.mission_id = $2 AND gmt.completed_at IS NULL
+		RETURNING gmt.progress, gm.quantity, gm.reward_rp
+	`, guild.ID, missionID, count).Scan(&progress, &quantity, &rewardRP)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		rollbackTransaction(s, transaction)
+		return ErrGuildMissionNotTargeted
+	}
+
+	if err != nil {
+		s.logger.Error(
+			"failed to add guild mission count",
+			zap.Error(err),
+			zap.Uint32("guildID", guild.ID),
+			zap.Uint32("missionID", missionID),
+		)
+		rollbackTransaction(s, transaction)
+		return err
+	}
+
+	if progress >= uint32(quantity) {
+		_, err = transaction.Exec(`
+			UPDATE guild_mission_targets SET completed_at = now() WHERE guild_id = $1 AND mission_id = $2
+		`, guild.ID, missionID)
+
+		if err != nil {
+			rollbackTransaction(s, transaction)
+			return err
+		}
+
+		err = guild.DonateRP(s, rewardRP, transaction)
+
+		if err != nil {
+			rollbackTransaction(s, transaction)
+			return err
+		}
+	}
+
+	err = transaction.Commit()
+
+	if err != nil {
+		s.logger.Error("failed to commit db transaction", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// writeGuildMissions writes the fixed number of mission slots, padding unused ones with zeroes.
+func writeGuildMissions(bf *byteframe.ByteFrame, missions []*GuildMission) {
+	for i := 0; i < guildMissionSlots; i++ {
+		if i >= len(missions) {
+			bf.WriteBytes(make([]byte, guildMissionEntrySize))
+			continue
+		}
+
+		mission := missions[i]
+
+		bf.WriteUint32(mission.ID)
+		bf.WriteUint32(mission.TextID)
+		bf.WriteUint32(mission.TargetID)
+		bf.WriteUint16(mission.Quantity)
+		bf.WriteUint16(uint16(mission.Difficulty))
+		bf.WriteUint16(uint16(mission.Progress))
+		bf.WriteUint8(mission.Category)
+		bf.WriteUint8(0x00) // Unk
+		bf.WriteUint8(mission.Difficulty)
+		bf.WriteUint32(uint32(mission.SetAt.Unix()))
+	}
+}
@@ -0,0 +1,57 @@
+package channelserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Andoryuuta/byteframe"
+)
+
+func TestWriteGuildMissions(t *testing.T) {
+	missions := []*GuildMission{
+		{
+			ID:         1,
+			TextID:     2,
+			TargetID:   3,
+			Quantity:   4,
+			Difficulty: 5,
+			Category:   6,
+			Progress:   7,
+			SetAt:      time.Unix(0x5F000000, 0),
+		},
+	}
+
+	bf := byteframe.NewByteFrame()
+	writeGuildMissions(bf, missions)
+
+	data := bf.Data()
+
+	if len(data) != guildMissionSlots*guildMissionEntrySize {
+		t.Fatalf("got %d bytes, want %d", len(data), guildMissionSlots*guildMissionEntrySize)
+	}
+
+	want := []byte{
+		0x00, 0x00, 0x00, 0x01, // ID
+		0x00, 0x00, 0x00, 0x02, // Text
+		0x00, 0x00, 0x00, 0x03, // Target
+		0x00, 0x04, // Quantity
+		0x00, 0x05, // Difficulty
+		0x00, 0x07, // Progress
+		0x06,                   // Category
+		0x00,                   // Unk
+		0x05,                   // Difficulty
+		0x5F, 0x00, 0x00, 0x00, // Set at
+	}
+
+	for i, b := range want {
+		if data[i] != b {
+			t.Fatalf("got entry % X, want % X", data[:guildMissionEntrySize], want)
+		}
+	}
+
+	for i, b := range data[guildMissionEntrySize:] {
+		if b != 0 {
+			t.Fatalf("got non-zero byte 0x%X at offset %d of the unused slots", b, i)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package channelserver
+
+import (
+	"fmt"
+
+	"github.com/Andoryuuta/Erupe/common/stringsupport"
+	"github.com/Andoryuuta/byteframe"
+)
+
+// Number of poogies living in the guild hall.
+const guildPugiCount = 3
+
+// Guild rank needed to unlock each outfit, guilds start with outfits 2, 3, 4 and 7 and can't unlock unlisted ones.
+var guildPugiOutfitRanks = map[uint8]uint16{
+	1:  2,
+	5:  4,
+	6:  6,
+	8:  8,
+	9:  10,
+	10: 12,
+	11: 14,
+	12: 17,
+}
+
+type GuildPugi struct {
+	PugiName1   string `db:"pugi_name_1"`
+	PugiName2   string `db:"pugi_name_2"`
+	PugiName3   string `db:"pugi_name_3"`
+	PugiOutfit1 uint8  `db:"pugi_outfit_1"`
+	PugiOutfit2 uint8  `db:"pugi_outfit_2"`
+	PugiOutfit3 uint8  `db:"pugi_outfit_3"`
+
+	// Bitmask of the outfits the guild has unlocked, outfit n being bit n-1.
+	PugiOutfits uint32 `db:"pugi_outfits"`
+}
+
+func (p *GuildPugi) PugiNames() []string {
+	return []string{p.PugiName1, p.PugiName2, p.PugiName3}
+}
+
+func (p *GuildPugi) PugiOutfitChoices() []uint8 {
+	return []uint8{p.PugiOutfit1, p.PugiOutfit2, p.PugiOutfit3}
+}
+
+// SetPugiName renames the given poogie, numbered from 1.
+func (p *GuildPugi) SetPugiName(num int, name string) error {
+	switch num {
+	case 1:
+		p.PugiName1 = name
+	case 2:
+		p.PugiName2 = name
+	case 3:
+		p.PugiName3 = name
+	default:
+		return fmt.Errorf("invalid pugi number %d", num)
+	}
+
+	return nil
+}
+
+// SetPugiOutfit dresses the given poogie, numbered from 1, in an outfit the guild has unlocked.
+func (p *GuildPugi) SetPugiOutfit(num int, outfit uint8) error {
+	if !p.HasPugiOutfit(outfit) {
+		return fmt.Errorf("pugi outfit %d has not been unlocked", outfit)
+	}
+
+	switch num {
+	case 1:
+		p.PugiOutfit1 = outfit
+	case 2:
+		p.PugiOutfit2 = outfit
+	case 3:
+		p.PugiOutfit3 = outfit
+	default:
+		return fmt.Errorf("invalid pugi number %d", num)
+	}
+
+	return nil
+}
+
+func (p *GuildPugi) HasPugiOutfit(outfit uint8) bool {
+	return outfit > 0 && outfit <= 32 && p.PugiOutfits&(1<<(outfit-1)) != 0
+}
+
+func (p *GuildPugi) UnlockPugiOutfit(outfit uint8) error {
+	if outfit == 0 || outfit > 32 {
+		return fmt.Errorf("invalid pugi outfit %d", outfit)
+	}
+
+	p.PugiOutfits |= 1 << (outfit - 1)
+
+	return nil
+}
+
+// CanUnlockPugiOutfit checks the guild has reached the rank the outfit needs.
+func (guild *Guild) CanUnlockPugiOutfit(outfit uint8) error {
+	rank, ok := guildPugiOutfitRanks[outfit]
+
+	if !ok {
+		return fmt.Errorf("pugi outfit %d can't be unlocked", outfit)
+	}
+
+	if guild.Rank() < rank {
+		return fmt.Errorf("pugi outfit %d needs guild rank %d, guild is rank %d", outfit, rank, guild.Rank())
+	}
+
+	return nil
+}
+
+// writeGuildPugi writes the poogie names and outfits of the guild info response.
+func writeGuildPugi(bf *byteframe.ByteFrame, p *GuildPugi) {
+	// An empty name gives the poogie its default japanese name
+	for _, name := range p.PugiNames() {
+		pugiName := stringsupport.MustConvertUTF8ToShiftJIS(name) + "\x00"
+
+		bf.WriteUint8(uint8(len(pugiName)))
+		bf.WriteBytes([]byte(pugiName))
+	}
+
+	// Outfits are sent twice, the second set's purpose is unknown
+	for i := 0; i < 2; i++ {
+		for _, outfit := range p.PugiOutfitChoices() {
+			bf.WriteUint8(outfit)
+		}
+	}
+
+	bf.WriteUint32(p.PugiOutfits)
+}
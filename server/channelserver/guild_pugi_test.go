@@ -0,0 +1,106 @@
+package channelserver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGuildPugiSetPugiName(t *testing.T) {
+	pugi := &GuildPugi{}
+
+	for num, name := range []string{"Alpha", "Beta", "Gamma"} {
+		if err := pugi.SetPugiName(num+1, name); err != nil {
+			t.Fatalf("SetPugiName(%d) returned error: %v", num+1, err)
+		}
+	}
+
+	if got, want := pugi.PugiNames(), []string{"Alpha", "Beta", "Gamma"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("PugiNames() = %v, want %v", got, want)
+	}
+
+	for _, num := range []int{0, 4} {
+		if err := pugi.SetPugiName(num, "Delta"); err == nil {
+			t.Errorf("SetPugiName(%d) accepted an invalid pugi number", num)
+		}
+	}
+}
+
+func TestGuildPugiOutfits(t *testing.T) {
+	pugi := &GuildPugi{}
+
+	if err := pugi.SetPugiOutfit(1, 2); err == nil {
+		t.Error("SetPugiOutfit accepted an outfit that was not unlocked")
+	}
+
+	for _, outfit := range []uint8{0, 33} {
+		if err := pugi.UnlockPugiOutfit(outfit); err == nil {
+			t.Errorf("UnlockPugiOutfit(%d) accepted an invalid outfit", outfit)
+		}
+	}
+
+	for _, outfit := range []uint8{1, 2, 32} {
+		if err := pugi.UnlockPugiOutfit(outfit); err != nil {
+			t.Fatalf("UnlockPugiOutfit(%d) returned error: %v", outfit, err)
+		}
+	}
+
+	if pugi.PugiOutfits != 0x80000003 {
+		t.Errorf("PugiOutfits = %#x, want %#x", pugi.PugiOutfits, 0x80000003)
+	}
+
+	tests := []struct {
+		outfit uint8
+		want   bool
+	}{
+		{0, false},
+		{1, true},
+		{2, true},
+		{3, false},
+		{32, true},
+		{33, false},
+	}
+
+	for _, tt := range tests {
+		if got := pugi.HasPugiOutfit(tt.outfit); got != tt.want {
+			t.Errorf("HasPugiOutfit(%d) = %v, want %v", tt.outfit, got, tt.want)
+		}
+	}
+
+	if err := pugi.SetPugiOutfit(3, 32); err != nil {
+		t.Fatalf("SetPugiOutfit returned error: %v", err)
+	}
+
+	if err := pugi.SetPugiOutfit(4, 1); err == nil {
+		t.Error("SetPugiOutfit accepted an invalid pugi number")
+	}
+
+	if got, want := pugi.PugiOutfitChoices(), []uint8{0, 0, 32}; !reflect.DeepEqual(got, want) {
+		t.Errorf("PugiOutfitChoices() = %v, want %v", got, want)
+	}
+}
+
+func TestGuildCanUnlockPugiOutfit(t *testing.T) {
+	tests := []struct {
+		rp      uint32
+		outfit  uint8
+		wantErr bool
+	}{
+		{0, 1, true},
+		{48, 1, false},
+		{48, 5, true},
+		{96, 5, true},
+		{144, 5, false},
+		{1199, 12, true},
+		{1200, 12, false},
+		{1200, 2, true},
+		{1200, 32, true},
+	}
+
+	for _, tt := range tests {
+		guild := &Guild{RP: tt.rp}
+
+		if err := guild.CanUnlockPugiOutfit(tt.outfit); (err != nil) != tt.wantErr {
+			t.Errorf("CanUnlockPugiOutfit(%d) with %d RP = %v, want error %v", tt.outfit, tt.rp, err, tt.wantErr)
+		}
+	}
+}
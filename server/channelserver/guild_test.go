@@ -0,0 +1,74 @@
+package channelserver
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGuildRank(t *testing.T) {
+	tests := []struct {
+		rp       uint32
+		rank     uint16
+		isMax    bool
+		maxUsers uint16
+	}{
+		{0, 0, false, 30},
+		{23, 0, false, 30},
+		{24, 1, false, 30},
+		{95, 2, false, 30},
+		{96, 3, false, 40},
+		{288, 7, false, 50},
+		{504, 10, false, 60},
+		{1199, 16, false, 60},
+		{1200, 17, true, 60},
+		{5000, 17, true, 60},
+	}
+
+	for _, tt := range tests {
+		guild := &Guild{RP: tt.rp}
+
+		if got := guild.Rank(); got != tt.rank {
+			t.Errorf("Rank() with %d RP = %d, want %d", tt.rp, got, tt.rank)
+		}
+
+		if got := guild.IsMaxRank(); got != tt.isMax {
+			t.Errorf("IsMaxRank() with %d RP = %v, want %v", tt.rp, got, tt.isMax)
+		}
+
+		if got := guild.MemberLimit(); got != tt.maxUsers {
+			t.Errorf("MemberLimit() with %d RP = %d, want %d", tt.rp, got, tt.maxUsers)
+		}
+	}
+}
+
+func TestGuildSuccessor(t *testing.T) {
+	now := time.Now()
+	activeSince := now.Add(-GuildLeaderInactivityPeriod)
+	recent := uint32(now.Add(-time.Hour).Unix())
+	stale := uint32(now.Add(-GuildLeaderInactivityPeriod - time.Hour).Unix())
+
+	leader := &GuildMember{CharID: 1, OrderIndex: 0, LastLogin: stale}
+	inactive := &GuildMember{CharID: 2, OrderIndex: 1, LastLogin: stale}
+	avoiding := &GuildMember{CharID: 3, OrderIndex: 2, LastLogin: recent, AvoidLeadership: true}
+	active := &GuildMember{CharID: 4, OrderIndex: 3, LastLogin: stale, LastActive: recent}
+
+	tests := []struct {
+		name          string
+		members       []*GuildMember
+		allowAvoiding bool
+		activeSince   time.Time
+		want          *GuildMember
+	}{
+		{"first in order", []*GuildMember{active, inactive, leader}, false, time.Time{}, inactive},
+		{"skips inactive members", []*GuildMember{leader, inactive, avoiding, active}, false, activeSince, active},
+		{"nobody active", []*GuildMember{leader, inactive, avoiding}, false, activeSince, nil},
+		{"falls back to avoiding members", []*GuildMember{leader, inactive, avoiding}, true, activeSince, avoiding},
+		{"leader alone", []*GuildMember{leader}, true, time.Time{}, nil},
+	}
+
+	for _, tt := range tests {
+		if got := guildSuccessor(tt.members, leader.CharID, tt.allowAvoiding, tt.activeSince); got != tt.want {
+			t.Errorf("%s: got %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package channelserver
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// How long a posted treasure hunt can be joined and reported before it expires.
+const GuildTreasureHuntDuration = 72 * time.Hour
+
+type GuildTreasureHunt struct {
+	ID          uint32       `db:"id"`
+	GuildID     uint32       `db:"guild_id"`
+	HostID      uint32       `db:"host_id"`
+	HostName    string       `db:"host_name"`
+	Destination uint32       `db:"destination"`
+	Level       uint32       `db:"level"`
+	HuntData    []byte       `db:"hunt_data"`
+	CreatedAt   time.Time    `db:"created_at"`
+	CompletedAt sql.NullTime `db:"completed_at"`
+	Hunters     uint32       `db:"hunters"`
+
+	// Whether the character the hunt was retrieved for has joined it.
+	Acquired bool `db:"acquired"`
+}
+
+// Selects hunts with the character in $1 as the viewer.
+const guildTreasureHuntSelectSQL = `
+	SELECT gh.id, gh.guild_id, gh.host_id, c.name AS host_name, gh.destination, gh.level, gh.hunt_data,
+		gh.created_at, gh.completed_at,
+		(SELECT COUNT(*) FROM guild_hunt_members ghm WHERE ghm.hunt_id = gh.id) AS hunters,
+		EXISTS(SELECT 1 FROM guild_hunt_members ghm WHERE ghm.hunt_id = gh.id AND ghm.character_id = $1) AS acquired
+	FROM guild_hunts gh
+		JOIN characters c ON c.id = gh.host_id
+`
+
+func guildTreasureHuntCutoff() time.Time {
+	return time.Now().Add(-GuildTreasureHuntDuration)
+}
+
+// TreasureHunts returns the guild's hunts that are still open.
+func (guild *Guild) TreasureHunts(s *Session, charID uint32) ([]*GuildTreasureHunt, error) {
+	hunts := make([]*GuildTreasureHunt, 0)
+
+	err := s.server.db.Select(&hunts, guildTreasureHuntSelectSQL+`
+		WHERE gh.guild_id = $3 AND gh.completed_at IS NULL AND gh.created_at > $2
+		ORDER BY gh.created_at DESC
+	`, charID, guildTreasureHuntCutoff(), guild.ID)
+
+	if err != nil {
+		s.logger.Error("failed to retrieve treasure hunts", zap.Error(err), zap.Uint32("guildID", guild.ID))
+		return nil, err
+	}
+
+	return hunts, nil
+}
+
+// GetActiveTreasureHunt returns the open hunt the character has joined, nil if there is none.
+func GetActiveTreasureHunt(s *Session, charID uint32) (*GuildTreasureHunt, error) {
+	hunts := make([]*GuildTreasureHunt, 0)
+
+	err := s.server.db.Select(&hunts, guildTreasureHuntSelectSQL+`
+		WHERE gh.completed_at IS NULL AND gh.created_at > $2
+			AND EXISTS(SELECT 1 FROM guild_hunt_members ghm WHERE ghm.hunt_id = gh.id AND ghm.character_id = $1)
+		ORDER BY gh.created_at DESC
+		LIMIT 1
+	`, charID, guildTreasureHuntCutoff())
+
+	if err != nil {
+		s.logger.Error("failed to retrieve active treasure hunt", zap.Error(err), zap.Uint32("charID", charID))
+		return nil, err
+	}
+
+	if len(hunts) == 0 {
+		return nil, nil
+	}
+
+	return hunts[0], nil
+}
+
+func GetTreasureHunt(s *Session, charID uint32, huntID uint32) (*GuildTreasureHunt, error) {
+	hunt := &GuildTreasureHunt{}
+
+	err := s.server.db.QueryRowx(guildTreasureHuntSelectSQL+`
+		WHERE gh.id = $2
+	`, charID, huntID).StructScan(hunt)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		s.logger.Error("failed to retrieve treasure hunt", zap.Error(err), zap.Uint32("huntID", huntID))
+		return nil, err
+	}
+
+	return hunt, nil
+}
+
+// GetTreasureHuntSouvenirs returns the completed hunts whose souvenirs the character hasn't claimed yet.
+func GetTreasureHuntSouvenirs(s *Session, charID uint32) ([]*GuildTreasureHunt, error) {
+	hunts := make([]*GuildTreasureHunt, 0)
+
+	err := s.server.db.Select(&hunts, guildTreasureHuntSelectSQL+`
+		WHERE gh.completed_at IS NOT NULL
+			AND EXISTS(
+				SELECT 1 FROM guild_hunt_members ghm
+				WHERE ghm.hunt_id = gh.id AND ghm.character_id = $1 AND NOT ghm.claimed
+			)
+		ORDER BY gh.completed_at
+	`, charID)
+
+	if err != nil {
+		s.logger.Error("failed to retrieve treasure hunt souvenirs", zap.Error(err), zap.Uint32("charID", charID))
+		return nil, err
+	}
+
+	return hunts, nil
+}
+
+// RegisterTreasureHunt posts a hunt for the guild, with the host as its first hunter.
+func (guild *Guild) RegisterTreasureHunt(s *Session, hostID uint32, destination uint32, level uint32, huntData []byte) (uint32, error) {
+	transaction, err := s.server.db.Begin()
+
+	if err != nil {
+		s.logger.Error("failed to start db transaction", zap.Error(err))
+		return 0, err
+	}
+
+	var huntID uint32
+
+	err = transaction.QueryRow(`
+		INSERT INTO guild_hunts (guild_id, host_id, destination, level, hunt_data) VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`, guild.ID, hostID, destination, level, huntData).Scan(&huntID)
+
+	if err != nil {
+		s.logger.Error("failed to register treasure hunt", zap.Error(err), zap.Uint32("guildID", guild.ID))
+		rollbackTransaction(s, transaction)
+		return 0, err
+	}
+
+	_, err = transaction.Exec(`
+		INSERT INTO guild_hunt_members (hunt_id, character_id) VALUES ($1, $2)
+	`, huntID, hostID)
+
+	if err != nil {
+		s.logger.Error("failed to add treasure hunt host", zap.Error(err), zap.Uint32("huntID", huntID))
+		rollbackTransaction(s, transaction)
+		return 0, err
+	}
+
+	err = transaction.Commit()
+
+	if err != nil {
+		s.logger.Error("failed to commit db transaction", zap.Error(err))
+		return 0, err
+	}
+
+	return huntID, nil
+}
+
+func (h *GuildTreasureHunt) IsOpen() bool {
+	return !h.CompletedAt.Valid && h.CreatedAt.After(guildTreasureHuntCutoff())
+}
+
+func (h *GuildTreasureHunt) Join(s *Session, charID uint32) error {
+	_, err := s.server.db.Exec(`
+		INSERT INTO guild_hunt_members (hunt_id, character_id) VALUES ($1, $2) ON CONFLICT DO NOTHING
+	`, h.ID, charID)
+
+	if err != nil {
+		s.logger.Error(
+			"failed to join treasure hunt",
+			zap.Error(err),
+			zap.Uint32("huntID", h.ID),
+			zap.Uint32("charID", charID),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (h *GuildTreasureHunt) Leave(s *Session, charID uint32) error {
+	_, err := s.server.db.Exec(`
+		DELETE FROM guild_hunt_members WHERE hunt_id = $1 AND character_id = $2
+	`, h.ID, charID)
+
+	if err != nil {
+		s.logger.Error(
+			"failed to leave treasure hunt",
+			zap.Error(err),
+			zap.Uint32("huntID", h.ID),
+			zap.Uint32("charID", charID),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (h *GuildTreasureHunt) Complete(s *Session) error {
+	_, err := s.server.db.Exec(`
+		UPDATE guild_hunts SET completed_at = now() WHERE id = $1 AND completed_at IS NULL
+	`, h.ID)
+
+	if err != nil {
+		s.logger.Error("failed to complete treasure hunt", zap.Error(err), zap.Uint32("huntID", h.ID))
+		return err
+	}
+
+	return nil
+}
+
+// ClaimTreasureHuntSouvenirs marks the souvenirs of the given completed hunts as claimed, or of all of them when none are given.
+func ClaimTreasureHuntSouvenirs(s *Session, charID uint32, huntIDs ...uint32) error {
+	query := `
+		UPDATE guild_hunt_members SET claimed = true
+		WHERE character_id = $1
+			AND hunt_id IN (SELECT id FROM guild_hunts WHERE completed_at IS NOT NULL)
+	`
+	args := []interface{}{charID}
+
+	if len(huntIDs) > 0 {
+		query += ` AND hunt_id = ANY($2)`
+		args = append(args, pq.Array(huntIDs))
+	}
+
+	_, err := s.server.db.Exec(query, args...)
+
+	if err != nil {
+		s.logger.Error("failed to claim treasure hunt souvenirs", zap.Error(err), zap.Uint32("charID", charID))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package channelserver
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+)
+
+func TestGuildTreasureHuntIsOpen(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name string
+		hunt GuildTreasureHunt
+		want bool
+	}{
+		{"new", GuildTreasureHunt{CreatedAt: now}, true},
+		{"almost expired", GuildTreasureHunt{CreatedAt: now.Add(-GuildTreasureHuntDuration + time.Minute)}, true},
+		{"expired", GuildTreasureHunt{CreatedAt: now.Add(-GuildTreasureHuntDuration - time.Minute)}, false},
+		{"completed", GuildTreasureHunt{CreatedAt: now, CompletedAt: sql.NullTime{Time: now, Valid: true}}, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.hunt.IsOpen(); got != tt.want {
+			t.Errorf("%s hunt: IsOpen() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
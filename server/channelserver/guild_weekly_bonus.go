@@ -0,0 +1,192 @@
+package channelserver
+
+import (
+	"time"
+
+	"github.com/Andoryuuta/byteframe"
+	"go.uber.org/zap"
+)
+
+// Weekly bonus tiers, the client picks the guild's tier from the members active during the previous week.
+var guildWeeklyBonusTiers = []struct {
+	ActiveMembers uint16
+	Bonus         uint16
+}{
+	{5, 1},
+	{10, 2},
+	{15, 3},
+	{20, 4},
+	{25, 5},
+	{30, 6},
+	{35, 7},
+	{40, 8},
+	{50, 9},
+	{60, 10},
+}
+
+// Weeks are stored as plain dates so the database timezone can't move them to another day.
+const guildWeekDateLayout = "2006-01-02"
+
+type GuildActivityType int
+
+const (
+	GuildActivityLogin GuildActivityType = iota
+	GuildActivityQuest
+)
+
+type GuildWeeklyActivity struct {
+	ActiveMembers    uint32 `db:"active_members"`
+	ExceptionalUsers uint32 `db:"exceptional_users"`
+}
+
+// guildWeekStart returns the start of the guild week containing t, weeks resetting on Monday midnight JST.
+func guildWeekStart(t time.Time) time.Time {
+	t = t.In(time.FixedZone("UTC+9", 9*60*60))
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+
+	return midnight.AddDate(0, 0, -((int(midnight.Weekday()) + 6) % 7))
+}
+
+// RecordGuildActivity counts a login or quest towards the character's guild for the current week.
+func RecordGuildActivity(s *Session, charID uint32, activity GuildActivityType) error {
+	guildData, err := GetCharacterGuildData(s, charID)
+
+	if err != nil {
+		return err
+	}
+
+	if guildData == nil || guildData.IsApplicant {
+		return nil
+	}
+
+	logins, quests := 0, 0
+
+	switch activity {
+	case GuildActivityLogin:
+		logins = 1
+	case GuildActivityQuest:
+		quests = 1
+	}
+
+	weekStart := guildWeekStart(time.Now())
+
+	transaction, err := s.server.db.Begin()
+
+	if err != nil {
+		s.logger.Error("failed to start db transaction", zap.Error(err))
+		return err
+	}
+
+	_, err = transaction.Exec(`
+		INSERT INTO guild_weekly_activity (guild_id, character_id, week_start, logins, quests) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (guild_id, character_id, week_start) DO UPDATE
+			SET logins = guild_weekly_activity.logins + $4, quests = guild_weekly_activity.quests + $5
+	`, guildData.GuildID, charID, weekStart.Format(guildWeekDateLayout), logins, quests)
+
+	if err != nil {
+		s.logger.Error(
+			"failed to record guild activity",
+			zap.Error(err),
+			zap.Uint32("guildID", guildData.GuildID),
+			zap.Uint32("charID", charID),
+		)
+		rollbackTransaction(s, transaction)
+		return err
+	}
+
+	_, err = transaction.Exec(`
+		UPDATE guild_characters SET last_active_at = now() WHERE character_id = $1
+	`, charID)
+
+	if err != nil {
+		s.logger.Error("failed to update guild member activity", zap.Error(err), zap.Uint32("charID", charID))
+		rollbackTransaction(s, transaction)
+		return err
+	}
+
+	// Only the current and previous weeks are ever read, older ones have been reset.
+	_, err = transaction.Exec(`
+		DELETE FROM guild_weekly_activity WHERE guild_id = $1 AND week_start < $2
+	`, guildData.GuildID, weekStart.AddDate(0, 0, -7).Format(guildWeekDateLayout))
+
+	if err != nil {
+		s.logger.Error("failed to reset guild activity", zap.Error(err), zap.Uint32("guildID", guildData.GuildID))
+		rollbackTransaction(s, transaction)
+		return err
+	}
+
+	err = transaction.Commit()
+
+	if err != nil {
+		s.logger.Error("failed to commit db transaction", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// WeeklyActivity returns how many members were active during the week starting at weekStart.
+func (guild *Guild) WeeklyActivity(s *Session, weekStart time.Time) (*GuildWeeklyActivity, error) {
+	activity := &GuildWeeklyActivity{}
+
+	err := s.server.db.QueryRowx(`
+		SELECT
+			(SELECT COUNT(*) FROM guild_weekly_activity WHERE guild_id = $1 AND week_start = $2) AS active_members,
+			least(
+				(SELECT COUNT(*) FROM guild_weekly_exceptional_users WHERE guild_id = $1 AND week_start = $2),
+				(SELECT COUNT(*) FROM guild_characters WHERE guild_id = $1)
+			) AS exceptional_users
+	`, guild.ID, weekStart.Format(guildWeekDateLayout)).StructScan(activity)
+
+	if err != nil {
+		s.logger.Error("failed to retrieve guild weekly activity", zap.Error(err), zap.Uint32("guildID", guild.ID))
+		return nil, err
+	}
+
+	return activity, nil
+}
+
+// AddExceptionalUser counts the character as an exceptional user for the current week.
+// Each character is only counted once a week, and the guild never has more exceptional users than members.
+func (guild *Guild) AddExceptionalUser(s *Session, charID uint32) error {
+	weekStart := guildWeekStart(time.Now())
+
+	_, err := s.server.db.Exec(`
+		INSERT INTO guild_weekly_exceptional_users (guild_id, character_id, week_start) VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING
+	`, guild.ID, charID, weekStart.Format(guildWeekDateLayout))
+
+	if err != nil {
+		s.logger.Error(
+			"failed to add guild exceptional user",
+			zap.Error(err),
+			zap.Uint32("guildID", guild.ID),
+			zap.Uint32("charID", charID),
+		)
+		return err
+	}
+
+	// Only the current and previous weeks are ever read, older ones have been reset.
+	_, err = s.server.db.Exec(`
+		DELETE FROM guild_weekly_exceptional_users WHERE guild_id = $1 AND week_start < $2
+	`, guild.ID, weekStart.AddDate(0, 0, -7).Format(guildWeekDateLayout))
+
+	if err != nil {
+		s.logger.Error("failed to reset guild exceptional users", zap.Error(err), zap.Uint32("guildID", guild.ID))
+		return err
+	}
+
+	return nil
+}
+
+func (a *GuildWeeklyActivity) Count() uint32 {
+	return a.ActiveMembers + a.ExceptionalUsers
+}
+
+// writeGuildWeeklyBonusTiers writes the tier table, the client reads 0x28 bytes worth of tiers.
+func writeGuildWeeklyBonusTiers(bf *byteframe.ByteFrame) {
+	for _, tier := range guildWeeklyBonusTiers {
+		bf.WriteUint16(tier.ActiveMembers)
+		bf.WriteUint16(tier.Bonus)
+	}
+}
@@ -0,0 +1,47 @@
+package channelserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Andoryuuta/byteframe"
+)
+
+func TestGuildWeekStart(t *testing.T) {
+	jst := time.FixedZone("UTC+9", 9*60*60)
+	monday := time.Date(2021, time.March, 1, 0, 0, 0, 0, jst)
+
+	tests := []struct {
+		t    time.Time
+		want time.Time
+	}{
+		{monday, monday},
+		{time.Date(2021, time.March, 3, 12, 30, 0, 0, jst), monday},
+		{time.Date(2021, time.March, 7, 23, 59, 59, 0, jst), monday},
+		{time.Date(2021, time.March, 8, 0, 0, 0, 0, jst), monday.AddDate(0, 0, 7)},
+		// Sunday 15:00 UTC is already Monday in Japan
+		{time.Date(2021, time.March, 7, 15, 0, 0, 0, time.UTC), monday.AddDate(0, 0, 7)},
+		{time.Date(2021, time.February, 28, 14, 59, 59, 0, time.UTC), monday.AddDate(0, 0, -7)},
+	}
+
+	for _, tt := range tests {
+		if got := guildWeekStart(tt.t); !got.Equal(tt.want) {
+			t.Errorf("guildWeekStart(%v) = %v, want %v", tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestWriteGuildWeeklyBonusTiers(t *testing.T) {
+	bf := byteframe.NewByteFrame()
+	writeGuildWeeklyBonusTiers(bf)
+
+	if len(bf.Data()) != 0x28 {
+		t.Fatalf("wrote %d bytes, want %d", len(bf.Data()), 0x28)
+	}
+
+	tiers := byteframe.NewByteFrameFromBytes(bf.Data())
+
+	if members, bonus := tiers.ReadUint16(), tiers.ReadUint16(); members != 5 || bonus != 1 {
+		t.Errorf("first tier = %d members for bonus %d, want 5 members for bonus 1", members, bonus)
+	}
+}
@@ -0,0 +1,121 @@
+package channelserver
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// Number of monsters the client can show on the guild hunting board.
+const guildHuntdataMaxEntries = 0xFF
+
+type GuildMonsterKills struct {
+	MonsterID uint32 `db:"monster_id"`
+	Kills     uint32 `db:"kills"`
+}
+
+// RecordGuildKills adds the monsters killed in a quest result to the hunter's guild for the current week.
+// Every party member returns the same kills, so they are only added for the first member of each guild to return
+// from the quest run in stage.
+func RecordGuildKills(s *Session, stage *Stage, result *QuestResult) error {
+	guildData, err := GetCharacterGuildData(s, result.CharID)
+
+	if err != nil {
+		return err
+	}
+
+	if guildData == nil || guildData.IsApplicant {
+		return nil
+	}
+
+	if stage != nil && !stage.claimGuildKills(guildData.GuildID) {
+		return nil
+	}
+
+	monsterIDs := make([]int64, 0)
+	kills := make([]int64, 0)
+
+	for monsterID, count := range result.Kills {
+		if count > 0 {
+			monsterIDs = append(monsterIDs, int64(monsterID))
+			kills = append(kills, int64(count))
+		}
+	}
+
+	if len(monsterIDs) == 0 {
+		return nil
+	}
+
+	_, err = s.server.db.Exec(`
+		INSERT INTO guild_weekly_kills (guild_id, week_start, monster_id, kills)
+		SELECT $1, $2, monster_id, kills FROM unnest($3::int[], $4::int[]) AS k(monster_id, kills)
+		ON CONFLICT (guild_id, week_start, monster_id) DO UPDATE
+			SET kills = guild_weekly_kills.kills + excluded.kills
+	`, guildData.GuildID, guildWeekStart(time.Now()).Format(guildWeekDateLayout), pq.Array(monsterIDs), pq.Array(kills))
+
+	if err != nil {
+		s.logger.Error(
+			"failed to record guild kills",
+			zap.Error(err),
+			zap.Uint32("guildID", guildData.GuildID),
+			zap.Uint32("charID", result.CharID),
+		)
+		return err
+	}
+
+	return nil
+}
+
+// WeeklyKills returns the monsters the guild has hunted during the week starting at weekStart.
+func (guild *Guild) WeeklyKills(s *Session, weekStart time.Time) ([]*GuildMonsterKills, error) {
+	kills := make([]*GuildMonsterKills, 0)
+
+	err := s.server.db.Select(&kills, `
+		SELECT monster_id, kills FROM guild_weekly_kills
+		WHERE guild_id = $1 AND week_start = $2
+		ORDER BY monster_id
+		LIMIT $3
+	`, guild.ID, weekStart.Format(guildWeekDateLayout), guildHuntdataMaxEntries)
+
+	if err != nil {
+		s.logger.Error("failed to retrieve guild weekly kills", zap.Error(err), zap.Uint32("guildID", guild.ID))
+		return nil, err
+	}
+
+	return kills, nil
+}
+
+// HasUnclaimedHuntdata reports whether the guild hunted this week and the member hasn't claimed the hunting board yet.
+func (gm *GuildMember) HasUnclaimedHuntdata(s *Session) (bool, error) {
+	var unclaimed bool
+
+	err := s.server.db.QueryRow(`
+		SELECT EXISTS(
+			SELECT 1 FROM guild_weekly_kills gwk
+				JOIN guild_characters gc ON gc.guild_id = gwk.guild_id
+			WHERE gc.character_id = $1 AND gwk.week_start = $2
+				AND (gc.hunt_data_claimed_week IS NULL OR gc.hunt_data_claimed_week < gwk.week_start)
+		)
+	`, gm.CharID, guildWeekStart(time.Now()).Format(guildWeekDateLayout)).Scan(&unclaimed)
+
+	if err != nil {
+		s.logger.Error("failed to check guild hunt data", zap.Error(err), zap.Uint32("charID", gm.CharID))
+		return false, err
+	}
+
+	return unclaimed, nil
+}
+
+func (gm *GuildMember) ClaimHuntdata(s *Session) error {
+	_, err := s.server.db.Exec(`
+		UPDATE guild_characters SET hunt_data_claimed_week = $2 WHERE character_id = $1
+	`, gm.CharID, guildWeekStart(time.Now()).Format(guildWeekDateLayout))
+
+	if err != nil {
+		s.logger.Error("failed to claim guild hunt data", zap.Error(err), zap.Uint32("charID", gm.CharID))
+		return err
+	}
+
+	return nil
+}
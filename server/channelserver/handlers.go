@@ -11,6 +11,7 @@ import (
 	"math/bits"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -177,14 +178,33 @@ func handleMsgSysAck(s *Session, p mhfpacket.MHFPacket) {}
 func handleMsgSysTerminalLog(s *Session, p mhfpacket.MHFPacket) {
 	pkt := p.(*mhfpacket.MsgSysTerminalLog)
 
-	/*
-		if pkt.LogID == 0{
-			fmt.Println("New log session")
+	logID := pkt.LogID
+
+	// Log IDs are only reused when they were issued to this character, anything else starts a new log session.
+	if logID != 0 {
+		owner, err := IsTerminalLogSessionOwner(s, logID)
+
+		if err != nil || !owner {
+			logID = 0
 		}
-	*/
+	}
+
+	if logID == 0 {
+		var err error
+		logID, err = CreateTerminalLogSession(s)
+
+		if err != nil {
+			doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+			return
+		}
+	}
+
+	if len(pkt.Entries) > 0 {
+		_ = SaveTerminalLogEntries(s, logID, pkt.Entries)
+	}
 
 	resp := byteframe.NewByteFrame()
-	resp.WriteUint32(0x98bd51a9) // LogID to use for requests after this.
+	resp.WriteUint32(logID) // LogID to use for requests after this.
 
 	doAckSimpleSucceed(s, pkt.AckHandle, resp.Data())
 }
@@ -196,6 +216,14 @@ func handleMsgSysLogin(s *Session, p mhfpacket.MHFPacket) {
 	s.charID = pkt.CharID0
 	s.Unlock()
 
+	_, err := s.server.db.Exec("UPDATE characters SET last_login = $1 WHERE id = $2", uint32(time.Now().Unix()), s.charID)
+
+	if err != nil {
+		s.logger.Error("failed to update last login", zap.Error(err), zap.Uint32("charID", s.charID))
+	}
+
+	_ = RecordGuildActivity(s, s.charID, GuildActivityLogin)
+
 	bf := byteframe.NewByteFrame()
 	bf.WriteUint32(uint32(time.Now().In(time.FixedZone("UTC+9", 9*60*60)).Unix())) // Unix timestamp
 
@@ -309,8 +337,33 @@ func handleMsgSysIssueLogkey(s *Session, p mhfpacket.MHFPacket) {
 
 func handleMsgSysRecordLog(s *Session, p mhfpacket.MHFPacket) {
 	pkt := p.(*mhfpacket.MsgSysRecordLog)
-	// remove a client returning to town from reserved slots to make sure the stage is hidden from board
-	delete(s.stage.reservedClientSlots, s.charID)
+
+	result, err := ParseQuestResult(s.charID, pkt.DataBuf)
+
+	if err != nil {
+		s.logger.Warn("Failed to parse quest result", zap.Error(err), zap.Uint32("charID", s.charID))
+	}
+
+	// The party isn't part of the record, it's taken from the stage reservations instead.
+	if s.stage != nil {
+		s.stage.Lock()
+		if result != nil {
+			for charID := range s.stage.reservedClientSlots {
+				result.Party = append(result.Party, charID)
+			}
+		}
+
+		// remove a client returning to town from reserved slots to make sure the stage is hidden from board
+		delete(s.stage.reservedClientSlots, s.charID)
+		s.stage.Unlock()
+	}
+
+	if result != nil {
+		_ = result.Save(s)
+		_ = RecordGuildActivity(s, s.charID, GuildActivityQuest)
+		_ = RecordGuildKills(s, s.stage, result)
+	}
+
 	doAckSimpleSucceed(s, pkt.AckHandle, []byte{0x00, 0x00, 0x00, 0x00})
 }
 
@@ -384,40 +437,7 @@ func doStageTransfer(s *Session, ackHandle uint32, stageID string) {
 
 		//Notify the entree client about all of the existing clients in the stage.
 		s.logger.Info("Notifying entree about existing stage clients")
-		s.stage.RLock()
-		clientNotif := byteframe.NewByteFrame()
-		for session := range s.stage.clients {
-			var cur mhfpacket.MHFPacket
-			cur = &mhfpacket.MsgSysInsertUser{
-				CharID: session.charID,
-			}
-			clientNotif.WriteUint16(uint16(cur.Opcode()))
-			cur.Build(clientNotif)
-
-			cur = &mhfpacket.MsgSysNotifyUserBinary{
-				CharID:     session.charID,
-				BinaryType: 1,
-			}
-			clientNotif.WriteUint16(uint16(cur.Opcode()))
-			cur.Build(clientNotif)
-
-			cur = &mhfpacket.MsgSysNotifyUserBinary{
-				CharID:     session.charID,
-				BinaryType: 2,
-			}
-			clientNotif.WriteUint16(uint16(cur.Opcode()))
-			cur.Build(clientNotif)
-
-			cur = &mhfpacket.MsgSysNotifyUserBinary{
-				CharID:     session.charID,
-				BinaryType: 3,
-			}
-			clientNotif.WriteUint16(uint16(cur.Opcode()))
-			cur.Build(clientNotif)
-		}
-		s.stage.RUnlock()
-		clientNotif.WriteUint16(0x0010) // End it.
-		s.QueueSend(clientNotif.Data())
+		s.QueueSend(makeStageClientsNotification(s.stage))
 
 		// Notify the client to duplicate the existing objects.
 		s.logger.Info("Notifying entree about existing stage objects")
@@ -465,6 +485,45 @@ func removeSessionFromStage(s *Session) {
 	}
 }
 
+// makeStageClientsNotification builds the packets inserting every client of the stage along with their user binaries.
+func makeStageClientsNotification(stage *Stage) []byte {
+	stage.RLock()
+	clientNotif := byteframe.NewByteFrame()
+	for session := range stage.clients {
+		var cur mhfpacket.MHFPacket
+		cur = &mhfpacket.MsgSysInsertUser{
+			CharID: session.charID,
+		}
+		clientNotif.WriteUint16(uint16(cur.Opcode()))
+		cur.Build(clientNotif)
+
+		cur = &mhfpacket.MsgSysNotifyUserBinary{
+			CharID:     session.charID,
+			BinaryType: 1,
+		}
+		clientNotif.WriteUint16(uint16(cur.Opcode()))
+		cur.Build(clientNotif)
+
+		cur = &mhfpacket.MsgSysNotifyUserBinary{
+			CharID:     session.charID,
+			BinaryType: 2,
+		}
+		clientNotif.WriteUint16(uint16(cur.Opcode()))
+		cur.Build(clientNotif)
+
+		cur = &mhfpacket.MsgSysNotifyUserBinary{
+			CharID:     session.charID,
+			BinaryType: 3,
+		}
+		clientNotif.WriteUint16(uint16(cur.Opcode()))
+		cur.Build(clientNotif)
+	}
+	stage.RUnlock()
+	clientNotif.WriteUint16(0x0010) // End it.
+
+	return clientNotif.Data()
+}
+
 func logoutPlayer(s *Session) {
 	if s.stage == nil {
 		return
@@ -484,6 +543,11 @@ func logoutPlayer(s *Session) {
 func handleMsgSysEnterStage(s *Session, p mhfpacket.MHFPacket) {
 	pkt := p.(*mhfpacket.MsgSysEnterStage)
 
+	if !canEnterStage(s, pkt.StageID) {
+		doAckSimpleFail(s, pkt.AckHandle, []byte{0x00, 0x00, 0x00, 0x00})
+		return
+	}
+
 	// Push our current stage ID to the movement stack before entering another one.
 	s.Lock()
 	s.stageMoveStack.Push(s.stageID)
@@ -515,6 +579,11 @@ func handleMsgSysBackStage(s *Session, p mhfpacket.MHFPacket) {
 func handleMsgSysMoveStage(s *Session, p mhfpacket.MHFPacket) {
 	pkt := p.(*mhfpacket.MsgSysMoveStage)
 
+	if !canEnterStage(s, pkt.StageID) {
+		doAckSimpleFail(s, pkt.AckHandle, []byte{0x00, 0x00, 0x00, 0x00})
+		return
+	}
+
 	// Push our current stage ID to the movement stack before entering another one.
 	s.Lock()
 	s.stageMoveStack.Push(s.stageID)
@@ -560,6 +629,11 @@ func handleMsgSysReserveStage(s *Session, p mhfpacket.MHFPacket) {
 	stageID := stripNullTerminator(pkt.StageID)
 	fmt.Printf("Got reserve stage req, TargetCount:%v, StageID:%v\n", pkt.Unk0, stageID)
 
+	if !canEnterStage(s, stageID) {
+		doAckSimpleFail(s, pkt.AckHandle, []byte{0x00, 0x00, 0x00, 0x00})
+		return
+	}
+
 	// Try to get the stage
 	s.server.stagesLock.Lock()
 	stage, gotStage := s.server.stages[stageID]
@@ -1188,38 +1262,60 @@ func handleMsgMhfLoaddata(s *Session, p mhfpacket.MHFPacket) {
 	doAckBufSucceed(s, pkt.AckHandle, data)
 }
 
-func handleMsgMhfListMember(s *Session, p mhfpacket.MHFPacket) {
-	pkt := p.(*mhfpacket.MsgMhfListMember)
+func handleMsgMhfEnumerateDistItem(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfEnumerateDistItem)
 
-	resp := byteframe.NewByteFrame()
-	resp.WriteUint32(0) // Members count. (Unsure of what kind of members these actually are, guild, party, COG subscribers, etc.)
+	distributions, err := GetDistributionsForCharacter(s, s.charID)
 
-	doAckBufSucceed(s, pkt.AckHandle, resp.Data())
-}
+	if err != nil {
+		doAckBufSucceed(s, pkt.AckHandle, make([]byte, 2))
+		return
+	}
 
-func handleMsgMhfOprMember(s *Session, p mhfpacket.MHFPacket) {}
+	bf := byteframe.NewByteFrame()
+	bf.WriteUint16(uint16(len(distributions)))
 
-func handleMsgMhfEnumerateDistItem(s *Session, p mhfpacket.MHFPacket) {
-	pkt := p.(*mhfpacket.MsgMhfEnumerateDistItem)
-	// uint16 number of entries
-	// 446 entry block
-	// uint32 claimID
-	// 00 00 00 00 00 00
-	// uint16 timesClaimable
-	// 00 00 00 00 FF FF FF FF FF FF FF FF FF FF FF FF 00 00 00 00 00 00 00 00 00
-	// uint8 stringLength
-	// string nullTermString
-	data, _ := hex.DecodeString("0001000000FF0000000000000000002000000000FFFFFFFFFFFFFFFFFFFFFFFF0000000000000000002F323020426F7820457870616E73696F6E73000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000")
-	doAckBufSucceed(s, pkt.AckHandle, data)
+	for _, d := range distributions {
+		var deadline uint32
+
+		if d.Deadline.Valid {
+			deadline = uint32(d.Deadline.Time.Unix())
+		}
+
+		name := stringsupport.MustConvertUTF8ToShiftJIS(d.Name) + "\x00"
+
+		entry := byteframe.NewByteFrame()
+		entry.WriteUint32(d.ID)
+		entry.WriteUint32(deadline)
+		entry.WriteUint32(0) // Unk
+		entry.WriteUint16(d.TimesAcceptable)
+		entry.WriteUint16(d.TimesAccepted)
+		entry.WriteUint16(0) // Unk
+
+		// Min/max HR, SR and GR requirements, 0xFFFF for none
+		for i := 0; i < 6; i++ {
+			entry.WriteUint16(0xFFFF)
+		}
+
+		entry.WriteBytes(make([]byte, 9)) // Unk
+		entry.WriteUint8(uint8(len(name)))
+		entry.WriteBytes([]byte(name))
+
+		data := entry.Data()
+
+		if len(data) < distributionEntrySize {
+			data = append(data, make([]byte, distributionEntrySize-len(data))...)
+		}
 
+		bf.WriteBytes(data[:distributionEntrySize])
+	}
+
+	doAckBufSucceed(s, pkt.AckHandle, bf.Data())
 }
 
 func handleMsgMhfApplyDistItem(s *Session, p mhfpacket.MHFPacket) {
-	// 0052a49100011f00000000000000010274db99 equipment box page
-	// 0052a48f00011e0000000000000001195dda5c item box page
-	// 0052a49400010700003ae30000000132d3a4d6 Item ID 3AE3
 	// HEADER:
-	// int32: Unique item hash for tracking server side purchases? Swapping across items didn't change image/cost/function etc.
+	// int32: Distribution ID
 	// int16: Number of distributed item types
 	// ITEM ENTRY
 	// int8:  distribution type
@@ -1232,29 +1328,77 @@ func handleMsgMhfApplyDistItem(s *Session, p mhfpacket.MHFPacket) {
 	// int16: Item when type 07
 	// int16: Unk
 	// int16: Number delivered in batch
-	// int32: Unique item hash for tracking server side purchases? Swapping across items didn't change image/cost/function etc.
+	// int32: Distribution ID
 	pkt := p.(*mhfpacket.MsgMhfApplyDistItem)
-	if pkt.RequestType == 0 {
-		doAckBufSucceed(s, pkt.AckHandle, []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00})
-	} else if pkt.RequestType == 0x000000FF {
-		data, _ := hex.DecodeString("0052a49100021f00000000000000140274db991e00000000000000140274db99")
-		doAckBufSucceed(s, pkt.AckHandle, data)
-	} else {
-		doAckBufSucceed(s, pkt.AckHandle, []byte{0x00, 0x00, 0x00, 0x00})
+
+	// The request type is the ID of the distribution being opened.
+	d, err := GetDistributionForCharacter(s, s.charID, pkt.RequestType)
+
+	if err != nil || d == nil || !d.CanAccept() {
+		doAckBufSucceed(s, pkt.AckHandle, make([]byte, 6))
+		return
+	}
+
+	items, err := d.Items(s)
+
+	if err != nil {
+		doAckBufSucceed(s, pkt.AckHandle, make([]byte, 6))
+		return
 	}
+
+	bf := byteframe.NewByteFrame()
+	bf.WriteUint32(d.ID)
+	bf.WriteUint16(uint16(len(items)))
+
+	for _, item := range items {
+		bf.WriteUint8(item.ItemType)
+		bf.WriteUint16(0) // Unk
+		bf.WriteUint16(item.ItemID)
+		bf.WriteUint16(0) // Unk
+		bf.WriteUint16(item.Quantity)
+		bf.WriteUint32(d.ID)
+	}
+
+	doAckBufSucceed(s, pkt.AckHandle, bf.Data())
 }
 
 func handleMsgMhfAcquireDistItem(s *Session, p mhfpacket.MHFPacket) {
 	pkt := p.(*mhfpacket.MsgMhfAcquireDistItem)
-	doAckSimpleSucceed(s, pkt.AckHandle, []byte{0x00, 0x00, 0x00, 0x00})
+
+	d, err := GetDistributionForCharacter(s, s.charID, pkt.DistributionID)
+
+	if err != nil || d == nil {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	err = d.Accept(s)
+
+	if err != nil {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	doAckSimpleSucceed(s, pkt.AckHandle, make([]byte, 4))
 }
 
 func handleMsgMhfGetDistDescription(s *Session, p mhfpacket.MHFPacket) {
 	pkt := p.(*mhfpacket.MsgMhfGetDistDescription)
-	// string for the associated message
-	data, _ := hex.DecodeString("007E43303547656E65726963204974656D20436C61696D204D6573736167657E4330300D0A596F752067657420736F6D65206B696E64206F66206974656D732070726F6261626C792E00000100")
-	//data, _ := hex.DecodeString("0075b750c1c2b17ac1cab652a1757e433035b8cbb3c6bd63c258b169aa41b0c87e433030a1760a0aa175b8cbb3c6bd63c258b169aa41b0c8a176a843c1caa44a31a6b8a141a569c258b169a2b0add30aa8a4a6e2aabaa175b8cbb3c6bd63a176a2b0adb6a143b3cca668a569c258b169a2b4adb6a14300000100")
-	doAckBufSucceed(s, pkt.AckHandle, data)
+
+	d, err := GetDistributionForCharacter(s, s.charID, pkt.EntryID)
+
+	if err != nil || d == nil {
+		doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	bf := byteframe.NewByteFrame()
+	bf.WriteUint8(0) // Unk
+	bf.WriteBytes([]byte(stringsupport.MustConvertUTF8ToShiftJIS(d.DisplayDescription()) + "\x00"))
+	bf.WriteUint8(0)      // Unk
+	bf.WriteUint16(0x100) // Unk
+
+	doAckBufSucceed(s, pkt.AckHandle, bf.Data())
 }
 
 func handleMsgMhfLoadFavoriteQuest(s *Session, p mhfpacket.MHFPacket) {
@@ -1372,12 +1516,6 @@ func handleMsgMhfCaravanRanking(s *Session, p mhfpacket.MHFPacket) {}
 
 func handleMsgMhfCaravanMyRank(s *Session, p mhfpacket.MHFPacket) {}
 
-func handleMsgMhfEnumerateCampaign(s *Session, p mhfpacket.MHFPacket) {}
-
-func handleMsgMhfStateCampaign(s *Session, p mhfpacket.MHFPacket) {}
-
-func handleMsgMhfApplyCampaign(s *Session, p mhfpacket.MHFPacket) {}
-
 func handleMsgMhfEnumerateItem(s *Session, p mhfpacket.MHFPacket) {}
 
 func handleMsgMhfAcquireItem(s *Session, p mhfpacket.MHFPacket) {}
@@ -1403,11 +1541,6 @@ func handleMsgMhfEnumerateQuest(s *Session, p mhfpacket.MHFPacket) {
 	updateRights(s)
 }
 
-func handleMsgMhfEnumerateEvent(s *Session, p mhfpacket.MHFPacket) {
-	pkt := p.(*mhfpacket.MsgMhfEnumerateEvent)
-	stubEnumerateNoResults(s, pkt.AckHandle)
-}
-
 func handleMsgMhfEnumeratePrice(s *Session, p mhfpacket.MHFPacket) {
 	pkt := p.(*mhfpacket.MsgMhfEnumeratePrice)
 	//resp := byteframe.NewByteFrame()
@@ -1445,39 +1578,10 @@ func handleMsgMhfEnumerateOrder(s *Session, p mhfpacket.MHFPacket) {
 
 func handleMsgMhfGetExtraInfo(s *Session, p mhfpacket.MHFPacket) {}
 
-func handleMsgMhfUpdateInterior(s *Session, p mhfpacket.MHFPacket) {}
-
-func handleMsgMhfEnumerateHouse(s *Session, p mhfpacket.MHFPacket) {}
-
-func handleMsgMhfUpdateHouse(s *Session, p mhfpacket.MHFPacket) {}
-
-func handleMsgMhfLoadHouse(s *Session, p mhfpacket.MHFPacket) {
-	pkt := p.(*mhfpacket.MsgMhfLoadHouse)
-	// Seems to generate same response regardless of upgrade tier
-	data, _ := hex.DecodeString("0000000000000000000000000000000000000000")
-	doAckBufSucceed(s, pkt.AckHandle, data)
-}
-
-func handleMsgMhfOperateWarehouse(s *Session, p mhfpacket.MHFPacket) {}
-
-func handleMsgMhfEnumerateWarehouse(s *Session, p mhfpacket.MHFPacket) {}
-
-func handleMsgMhfUpdateWarehouse(s *Session, p mhfpacket.MHFPacket) {}
-
-func handleMsgMhfAcquireTitle(s *Session, p mhfpacket.MHFPacket) {}
-
-func handleMsgMhfEnumerateTitle(s *Session, p mhfpacket.MHFPacket) {}
-
 func handleMsgMhfEnumerateUnionItem(s *Session, p mhfpacket.MHFPacket) {}
 
 func handleMsgMhfUpdateUnionItem(s *Session, p mhfpacket.MHFPacket) {}
 
-func handleMsgMhfCreateJoint(s *Session, p mhfpacket.MHFPacket) {}
-
-func handleMsgMhfOperateJoint(s *Session, p mhfpacket.MHFPacket) {}
-
-func handleMsgMhfInfoJoint(s *Session, p mhfpacket.MHFPacket) {}
-
 func handleMsgMhfInfoFesta(s *Session, p mhfpacket.MHFPacket) {
 	pkt := p.(*mhfpacket.MsgMhfInfoFesta)
 
@@ -1612,164 +1716,109 @@ func handleMsgMhfEnterTournamentQuest(s *Session, p mhfpacket.MHFPacket) {}
 
 func handleMsgMhfAcquireTournament(s *Session, p mhfpacket.MHFPacket) {}
 
-func handleMsgMhfGetAchievement(s *Session, p mhfpacket.MHFPacket) {
-	pkt := p.(*mhfpacket.MsgMhfGetAchievement)
+// scenarioCounterEntry is a single story entry advertised by MsgMhfInfoScenarioCounter.
+type scenarioCounterEntry struct {
+	MainID     uint32
+	CategoryID uint8
+}
 
-	achievementStruct := []struct {
-		ID   uint8  // Main ID
-		Unk0 uint8  // always FF
-		Unk1 uint16 // 0x05 0x00
-		Unk2 uint32 // 0x01 0x0A 0x05 0x00
-	}{
-		{ID: 0, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 1, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 2, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 3, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 4, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 5, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 6, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 7, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 8, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 9, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 10, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 11, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 12, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 13, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 14, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 15, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 16, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 17, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 18, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 19, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 20, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 21, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 22, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 23, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 24, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 25, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 26, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 27, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 28, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 29, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 30, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 31, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 32, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 33, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 34, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 35, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 36, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 37, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 38, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 39, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 40, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 41, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 42, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 43, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 44, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 45, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 46, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 47, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 48, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 49, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 50, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 51, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 52, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 53, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 54, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 55, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 56, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 57, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 58, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-		{ID: 59, Unk0: 0xFF, Unk1: 0, Unk2: 0},
-	}
-	resp := byteframe.NewByteFrame()
-	resp.WriteUint8(uint8(len(achievementStruct))) // Entry count
-	for _, entry := range achievementStruct {
-		resp.WriteUint8(entry.ID)
-		resp.WriteUint8(entry.Unk0)
-		resp.WriteUint16(entry.Unk1)
-		resp.WriteUint32(entry.Unk2)
+// getScenarioCounterEntries builds the scenario counter from the scenario files in the bin folder.
+// Files are expected to be named in Fist's format, as served by handleMsgSysGetFile.
+func getScenarioCounterEntries(s *Session) []scenarioCounterEntry {
+	files, err := ioutil.ReadDir(filepath.Join(s.server.erupeConfig.BinPath, "scenarios"))
+	if err != nil {
+		s.logger.Warn("Failed to read scenarios folder", zap.Error(err))
+		return nil
 	}
-	doAckBufSucceed(s, pkt.AckHandle, resp.Data())
 
-}
+	seen := make(map[scenarioCounterEntry]bool)
+	entries := make([]scenarioCounterEntry, 0)
+	for _, file := range files {
+		var categoryID, mainID, flags, chapterID int
+		n, err := fmt.Sscanf(file.Name(), "%d_0_0_0_S%d_T%d_C%d.bin", &categoryID, &mainID, &flags, &chapterID)
+		if err != nil || n != 4 {
+			continue
+		}
 
-func handleMsgMhfResetAchievement(s *Session, p mhfpacket.MHFPacket) {}
+		entry := scenarioCounterEntry{
+			MainID:     uint32(mainID),
+			CategoryID: uint8(categoryID),
+		}
+		if seen[entry] {
+			continue
+		}
+		seen[entry] = true
+		entries = append(entries, entry)
+	}
 
-func handleMsgMhfAddAchievement(s *Session, p mhfpacket.MHFPacket) {}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].CategoryID != entries[j].CategoryID {
+			return entries[i].CategoryID < entries[j].CategoryID
+		}
+		return entries[i].MainID < entries[j].MainID
+	})
 
-func handleMsgMhfPaymentAchievement(s *Session, p mhfpacket.MHFPacket) {}
+	// The entry count is a single byte.
+	if len(entries) > 0xFF {
+		entries = entries[:0xFF]
+	}
 
-func handleMsgMhfDisplayedAchievement(s *Session, p mhfpacket.MHFPacket) {}
+	return entries
+}
 
 func handleMsgMhfInfoScenarioCounter(s *Session, p mhfpacket.MHFPacket) {
-
 	pkt := p.(*mhfpacket.MsgMhfInfoScenarioCounter)
 
-	scenarioCounter := []struct {
-		Unk0 uint32 // Main ID?
-		Unk1 uint8
-		Unk2 uint8
-	}{
-		{
-			Unk0: 0x00000000,
-			Unk1: 1,
-			Unk2: 4,
-		},
-		{
-			Unk0: 0x00000001,
-			Unk1: 1,
-			Unk2: 4,
-		},
-		{
-			Unk0: 0x00000002,
-			Unk1: 1,
-			Unk2: 4,
-		},
-		{
-			Unk0: 0x00000003,
-			Unk1: 1,
-			Unk2: 4,
-		},
-	}
+	scenarioCounter := getScenarioCounterEntries(s)
 
 	resp := byteframe.NewByteFrame()
 	resp.WriteUint8(uint8(len(scenarioCounter))) // Entry count
 	for _, entry := range scenarioCounter {
-		resp.WriteUint32(entry.Unk0)
-		resp.WriteUint8(entry.Unk1)
-		resp.WriteUint8(entry.Unk2)
-	}
-
-	doAckBufSucceed(s, pkt.AckHandle, resp.Data())
-
-	// DEBUG, DELETE ME!
-	/*
-		data, err := ioutil.ReadFile(filepath.Join(s.server.erupeConfig.BinPath, "debug/info_scenario_counter_resp.bin"))
-		if err != nil {
-			panic(err)
+		resp.WriteUint32(entry.MainID)
+
+		// Categories 3 (other), 6 (pallone) and 7 (diva) are exchanged for items at the counter.
+		switch entry.CategoryID {
+		case 3, 6, 7:
+			resp.WriteBool(true)
+		default:
+			resp.WriteBool(false)
 		}
 
-		doAckBufSucceed(s, pkt.AckHandle, data)
-	*/
+		resp.WriteUint8(entry.CategoryID)
+	}
 
+	doAckBufSucceed(s, pkt.AckHandle, resp.Data())
 }
 
 func handleMsgMhfSaveScenarioData(s *Session, p mhfpacket.MHFPacket) {
 	pkt := p.(*mhfpacket.MsgMhfSaveScenarioData)
 
-	// Do this ack manually because it uses a non-(0|1) error code
-	s.QueueSendMHF(&mhfpacket.MsgSysAck{
-		AckHandle:        pkt.AckHandle,
-		IsBufferResponse: false,
-		ErrorCode:        0x40,
-		AckData:          []byte{0x00, 0x00, 0x00, 0x40},
-	})
+	dumpSaveData(s, pkt.RawDataPayload, "_scenariodata")
+
+	_, err := s.server.db.Exec("UPDATE characters SET scenariodata=$1 WHERE id=$2", pkt.RawDataPayload, s.charID)
+	if err != nil {
+		s.logger.Error("Failed to update scenariodata savedata in db", zap.Error(err), zap.Uint32("charID", s.charID))
+		doAckSimpleFail(s, pkt.AckHandle, []byte{0x00, 0x00, 0x00, 0x00})
+		return
+	}
+
+	doAckSimpleSucceed(s, pkt.AckHandle, []byte{0x00, 0x00, 0x00, 0x00})
 }
 
 func handleMsgMhfLoadScenarioData(s *Session, p mhfpacket.MHFPacket) {
 	pkt := p.(*mhfpacket.MsgMhfLoadScenarioData)
-	doAckBufSucceed(s, pkt.AckHandle, []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00})
+	var data []byte
+	err := s.server.db.QueryRow("SELECT scenariodata FROM characters WHERE id = $1", s.charID).Scan(&data)
+	if err != nil {
+		s.logger.Error("Failed to get scenariodata savedata from db", zap.Error(err), zap.Uint32("charID", s.charID))
+	}
+
+	if len(data) > 0 {
+		doAckBufSucceed(s, pkt.AckHandle, data)
+	} else {
+		// No story progress yet
+		doAckBufSucceed(s, pkt.AckHandle, make([]byte, 10))
+	}
 }
 
 func handleMsgMhfGetBbsSnsStatus(s *Session, p mhfpacket.MHFPacket) {}
@@ -1793,23 +1842,6 @@ func handleMsgMhfUpdateEtcPoint(s *Session, p mhfpacket.MHFPacket) {
 	doAckSimpleSucceed(s, pkt.AckHandle, []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00})
 }
 
-func handleMsgMhfGetMyhouseInfo(s *Session, p mhfpacket.MHFPacket) {
-	pkt := p.(*mhfpacket.MsgMhfGetMyhouseInfo)
-	// another save potentially since it can be updated?
-	// set first byte to 1 to avoid pop up every time without save
-	body := make([]byte, 0x16A)
-	// parity with the only packet capture available
-	//body[0] = 10;
-	//body[21] = 10;
-	doAckBufSucceed(s, pkt.AckHandle, body)
-}
-
-func handleMsgMhfUpdateMyhouseInfo(s *Session, p mhfpacket.MHFPacket) {
-	pkt := p.(*mhfpacket.MsgMhfUpdateMyhouseInfo)
-	// looks to be the sized datachunk from above without the size bytes, quite possibly intended to be persistent
-	doAckSimpleSucceed(s, pkt.AckHandle, []byte{0x00, 0x00, 0x00, 0x00})
-}
-
 func handleMsgMhfGetWeeklySchedule(s *Session, p mhfpacket.MHFPacket) {
 	pkt := p.(*mhfpacket.MsgMhfGetWeeklySchedule)
 	//japanese timestamps as client needs to be in japanese locale
@@ -1998,23 +2030,61 @@ func handleMsgMhfSavePlateBox(s *Session, p mhfpacket.MHFPacket) {
 	doAckSimpleSucceed(s, pkt.AckHandle, []byte{0x00, 0x00, 0x00, 0x00})
 }
 
+// Size of the guild card data when a character hasn't saved one yet.
+const defaultGuildcardSize = 0x20
+
+const maxGuildcardSize = 0x400
+
+// guildcardOwner returns the character whose guild card was requested, zero being sent for our own card.
+func guildcardOwner(s *Session, charID uint32) uint32 {
+	if charID == 0 {
+		return s.charID
+	}
+
+	return charID
+}
+
 func handleMsgMhfReadGuildcard(s *Session, p mhfpacket.MHFPacket) {
 	pkt := p.(*mhfpacket.MsgMhfReadGuildcard)
 
-	resp := byteframe.NewByteFrame()
-	resp.WriteUint32(0)
-	resp.WriteUint32(0)
-	resp.WriteUint32(0)
-	resp.WriteUint32(0)
-	resp.WriteUint32(0)
-	resp.WriteUint32(0)
-	resp.WriteUint32(0)
-	resp.WriteUint32(0)
+	charID := guildcardOwner(s, pkt.CharID)
 
-	doAckBufSucceed(s, pkt.AckHandle, resp.Data())
+	var data []byte
+	err := s.server.db.QueryRow("SELECT guild_card FROM characters WHERE id = $1", charID).Scan(&data)
+
+	if err != nil {
+		s.logger.Error("failed to retrieve guild card", zap.Error(err), zap.Uint32("charID", charID))
+		doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	if len(data) == 0 {
+		data = make([]byte, defaultGuildcardSize)
+	}
+
+	doAckBufSucceed(s, pkt.AckHandle, data)
 }
 
-func handleMsgMhfUpdateGuildcard(s *Session, p mhfpacket.MHFPacket) {}
+func handleMsgMhfUpdateGuildcard(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfUpdateGuildcard)
+
+	if len(pkt.RawDataPayload) > maxGuildcardSize {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	dumpSaveData(s, pkt.RawDataPayload, "_guildcard")
+
+	_, err := s.server.db.Exec("UPDATE characters SET guild_card = $1 WHERE id = $2", pkt.RawDataPayload, s.charID)
+
+	if err != nil {
+		s.logger.Error("failed to update guild card", zap.Error(err), zap.Uint32("charID", s.charID))
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	doAckSimpleSucceed(s, pkt.AckHandle, make([]byte, 4))
+}
 
 func handleMsgMhfReadBeatLevel(s *Session, p mhfpacket.MHFPacket) {
 	pkt := p.(*mhfpacket.MsgMhfReadBeatLevel)
@@ -2105,31 +2175,6 @@ func handleMsgMhfSavePartner(s *Session, p mhfpacket.MHFPacket) {
 	doAckSimpleSucceed(s, pkt.AckHandle, []byte{0x00, 0x00, 0x00, 0x00})
 }
 
-func handleMsgMhfGetGuildMissionList(s *Session, p mhfpacket.MHFPacket) {
-	pkt := p.(*mhfpacket.MsgMhfGetGuildMissionList)
-
-	decoded, err := hex.DecodeString("000694610000023E000112990023000100000200015DDD232100069462000002F30000005F000C000200000300025DDD232100069463000002EA0000005F0006000100000100015DDD23210006946400000245000000530010000200000400025DDD232100069465000002B60001129B0019000100000200015DDD232100069466000003DC0000001B0010000100000600015DDD232100069467000002DA000112A00019000100000400015DDD232100069468000002A800010DEF0032000200000200025DDD2321000694690000045500000022003C000200000600025DDD23210006946A00000080000122D90046000200000300025DDD23210006946B000001960000003B000A000100000100015DDD23210006946C0000049200000046005A000300000600035DDD23210006946D000000A4000000260018000200000600025DDD23210006946E0000017A00010DE40096000300000100035DDD23210006946F000001BE0000005E0014000200000400025DDD2355000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000")
-
-	if err != nil {
-		panic(err)
-	}
-
-	doAckBufSucceed(s, pkt.AckHandle, decoded)
-}
-
-func handleMsgMhfGetGuildMissionRecord(s *Session, p mhfpacket.MHFPacket) {
-	pkt := p.(*mhfpacket.MsgMhfGetGuildMissionRecord)
-
-	// No guild mission records = 0x190 empty bytes
-	doAckBufSucceed(s, pkt.AckHandle, make([]byte, 0x190))
-}
-
-func handleMsgMhfAddGuildMissionCount(s *Session, p mhfpacket.MHFPacket) {}
-
-func handleMsgMhfSetGuildMissionTarget(s *Session, p mhfpacket.MHFPacket) {}
-
-func handleMsgMhfCancelGuildMissionTarget(s *Session, p mhfpacket.MHFPacket) {}
-
 func handleMsgMhfLoadOtomoAirou(s *Session, p mhfpacket.MHFPacket) {
 	pkt := p.(*mhfpacket.MsgMhfLoadOtomoAirou)
 	// load partnyaa from database
@@ -2246,41 +2291,6 @@ func handleMsgMhfSaveDecoMyset(s *Session, p mhfpacket.MHFPacket) {
 
 func handleMsgMhfReserve010F(s *Session, p mhfpacket.MHFPacket) {}
 
-func handleMsgMhfLoadGuildCooking(s *Session, p mhfpacket.MHFPacket) {
-	pkt := p.(*mhfpacket.MsgMhfLoadGuildCooking)
-	// MealData
-	// uint16 meal count
-	// Meal
-	// uint32 hash?
-	// uint32 unk
-	// uint32 success level
-	// uint32 expiration timestamp
-
-	// encourage food
-	data := []byte{0x00, 0x01, 0x0F, 0x51, 0x97, 0xFF, 0x00, 0x00, 0x02, 0xC4, 0x00, 0x00, 0x00, 0x03, 0x5F, 0xFC, 0x0B, 0x51}
-	doAckBufSucceed(s, pkt.AckHandle, data)
-
-	//data := []byte{0x00, 0x01, 0x1C, 0x72, 0x54, 0x15, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0x5F, 0xF8, 0x2F, 0xE1}
-	//doAckBufSucceed(s, pkt.AckHandle, data)
-}
-
-func handleMsgMhfRegistGuildCooking(s *Session, p mhfpacket.MHFPacket) {
-	pkt := p.(*mhfpacket.MsgMhfRegistGuildCooking)
-	doAckSimpleSucceed(s, pkt.AckHandle, []byte{0x01, 0x00})
-}
-
-func handleMsgMhfLoadGuildAdventure(s *Session, p mhfpacket.MHFPacket) {
-	pkt := p.(*mhfpacket.MsgMhfLoadGuildAdventure)
-	data := []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
-	doAckBufSucceed(s, pkt.AckHandle, data)
-}
-
-func handleMsgMhfRegistGuildAdventure(s *Session, p mhfpacket.MHFPacket) {}
-
-func handleMsgMhfAcquireGuildAdventure(s *Session, p mhfpacket.MHFPacket) {}
-
-func handleMsgMhfChargeGuildAdventure(s *Session, p mhfpacket.MHFPacket) {}
-
 func handleMsgMhfLoadLegendDispatch(s *Session, p mhfpacket.MHFPacket) {
 	pkt := p.(*mhfpacket.MsgMhfLoadLegendDispatch)
 	data := []byte{0x03, 0x00, 0x00, 0x00, 0x00, 0x5e, 0x01, 0x8d, 0x40, 0x00, 0x00, 0x00, 0x00, 0x5e, 0x02, 0xde, 0xc0, 0x00, 0x00, 0x00, 0x00, 0x5e, 0x04, 0x30, 0x40}
@@ -2348,21 +2358,6 @@ func handleMsgMhfSaveHunterNavi(s *Session, p mhfpacket.MHFPacket) {
 
 func handleMsgMhfRegistSpabiTime(s *Session, p mhfpacket.MHFPacket) {}
 
-func handleMsgMhfGetGuildWeeklyBonusMaster(s *Session, p mhfpacket.MHFPacket) {
-	pkt := p.(*mhfpacket.MsgMhfGetGuildWeeklyBonusMaster)
-
-	// Values taken from brand new guild capture
-	doAckBufSucceed(s, pkt.AckHandle, make([]byte, 0x28))
-}
-func handleMsgMhfGetGuildWeeklyBonusActiveCount(s *Session, p mhfpacket.MHFPacket) {
-	pkt := p.(*mhfpacket.MsgMhfGetGuildWeeklyBonusActiveCount)
-
-	// Values taken from brand new guild capture
-	doAckBufSucceed(s, pkt.AckHandle, make([]byte, 0x03))
-}
-
-func handleMsgMhfAddGuildWeeklyBonusExceptionalUser(s *Session, p mhfpacket.MHFPacket) {}
-
 func handleMsgMhfGetTowerInfo(s *Session, p mhfpacket.MHFPacket) {
 	pkt := p.(*mhfpacket.MsgMhfGetTowerInfo)
 
@@ -3159,12 +3154,6 @@ func handleMsgMhfGetLobbyCrowd(s *Session, p mhfpacket.MHFPacket) {
 
 func handleMsgSysReserve180(s *Session, p mhfpacket.MHFPacket) {}
 
-func handleMsgMhfGuildHuntdata(s *Session, p mhfpacket.MHFPacket) {
-	pkt := p.(*mhfpacket.MsgMhfGuildHuntdata)
-	data := []byte{0x01, 0xFE}
-	doAckBufSucceed(s, pkt.AckHandle, data)
-}
-
 func handleMsgMhfAddKouryouPoint(s *Session, p mhfpacket.MHFPacket) {
 	// hunting with both ranks maxed gets you these
 	pkt := p.(*mhfpacket.MsgMhfAddKouryouPoint)
@@ -3409,8 +3398,6 @@ func handleMsgSysReserve19F(s *Session, p mhfpacket.MHFPacket) {}
 
 func handleMsgMhfUpdateForceGuildRank(s *Session, p mhfpacket.MHFPacket) {}
 
-func handleMsgMhfResetTitle(s *Session, p mhfpacket.MHFPacket) {}
-
 // "Enumrate_guild_msg_board"
 func handleMsgSysReserve202(s *Session, p mhfpacket.MHFPacket) {
 }
@@ -0,0 +1,101 @@
+package channelserver
+
+import (
+	"github.com/Andoryuuta/Erupe/network/mhfpacket"
+	"github.com/Andoryuuta/byteframe"
+)
+
+func handleMsgMhfGetAchievement(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfGetAchievement)
+
+	achievements, err := GetAchievements(s, s.charID)
+
+	if err != nil {
+		doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	resp := byteframe.NewByteFrame()
+	resp.WriteUint8(uint8(len(achievements))) // Entry count
+	for _, achievement := range achievements {
+		rank, next, progress := achievement.Rank()
+
+		if next > 0xFFFF {
+			next = 0xFFFF
+		}
+
+		if progress > 0xFFFF {
+			progress = 0xFFFF
+		}
+
+		resp.WriteUint8(achievement.ID)
+
+		// 0xFF until the first rank is reached
+		if rank == 0 {
+			resp.WriteUint8(0xFF)
+		} else {
+			resp.WriteUint8(rank)
+		}
+
+		resp.WriteUint16(uint16(next))
+		resp.WriteBool(rank > achievement.DisplayedRank) // Show the rank up notification
+		resp.WriteUint8(0)                               // Unk
+		resp.WriteUint16(uint16(progress))
+	}
+	doAckBufSucceed(s, pkt.AckHandle, resp.Data())
+}
+
+func handleMsgMhfResetAchievement(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfResetAchievement)
+
+	achievement := &Achievement{ID: pkt.AchievementID}
+
+	if pkt.AchievementID >= AchievementCount || achievement.Save(s, s.charID) != nil {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	doAckSimpleSucceed(s, pkt.AckHandle, make([]byte, 4))
+}
+
+func handleMsgMhfAddAchievement(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfAddAchievement)
+
+	// Doesn't expect a response
+	_ = AddAchievementProgress(s, s.charID, pkt.AchievementID)
+}
+
+func handleMsgMhfPaymentAchievement(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfPaymentAchievement)
+
+	achievement, err := GetAchievement(s, s.charID, pkt.AchievementID)
+
+	if err != nil {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	err = achievement.PayRewards(s, s.charID)
+
+	if err != nil {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	doAckSimpleSucceed(s, pkt.AckHandle, make([]byte, 4))
+}
+
+func handleMsgMhfDisplayedAchievement(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfDisplayedAchievement)
+
+	achievement, err := GetAchievement(s, s.charID, pkt.AchievementID)
+
+	if err != nil {
+		return
+	}
+
+	achievement.DisplayedRank, _, _ = achievement.Rank()
+
+	// Doesn't expect a response
+	_ = achievement.Save(s, s.charID)
+}
@@ -0,0 +1,125 @@
+package channelserver
+
+import (
+	"github.com/Andoryuuta/Erupe/common/stringsupport"
+	"github.com/Andoryuuta/Erupe/network/mhfpacket"
+	"github.com/Andoryuuta/byteframe"
+)
+
+func handleMsgMhfEnumerateEvent(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfEnumerateEvent)
+
+	events, err := GetActiveEvents(s)
+
+	if err != nil {
+		stubEnumerateNoResults(s, pkt.AckHandle)
+		return
+	}
+
+	bf := byteframe.NewByteFrame()
+	bf.WriteUint32(uint32(len(events)))
+
+	for _, event := range events {
+		bf.WriteUint32(event.ID)
+		bf.WriteUint16(event.EventType)
+		bf.WriteUint16(0) // Unk
+		bf.WriteUint32(uint32(event.StartTime.Unix()))
+		bf.WriteUint32(uint32(event.EndTime.Unix()))
+	}
+
+	doAckBufSucceed(s, pkt.AckHandle, bf.Data())
+}
+
+func handleMsgMhfEnumerateCampaign(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfEnumerateCampaign)
+
+	campaigns, err := GetCampaignsForCharacter(s, s.charID)
+
+	if err != nil {
+		stubEnumerateNoResults(s, pkt.AckHandle)
+		return
+	}
+
+	bf := byteframe.NewByteFrame()
+	bf.WriteUint32(uint32(len(campaigns)))
+
+	for _, campaign := range campaigns {
+		title := stringsupport.MustConvertUTF8ToShiftJIS(campaign.Title) + "\x00"
+		description := stringsupport.MustConvertUTF8ToShiftJIS(campaign.Description) + "\x00"
+
+		bf.WriteUint32(campaign.ID)
+		bf.WriteUint32(uint32(campaign.StartTime.Unix()))
+		bf.WriteUint32(uint32(campaign.EndTime.Unix()))
+		bf.WriteUint16(campaign.MinHR)
+		bf.WriteUint16(campaign.MaxHR)
+		bf.WriteBool(campaign.Code.Valid)
+		bf.WriteBool(campaign.Applied)
+		bf.WriteUint8(uint8(len(title)))
+		bf.WriteUint16(uint16(len(description)))
+		bf.WriteBytes([]byte(title))
+		bf.WriteBytes([]byte(description))
+	}
+
+	doAckBufSucceed(s, pkt.AckHandle, bf.Data())
+}
+
+func handleMsgMhfStateCampaign(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfStateCampaign)
+
+	campaign, err := GetCampaignByID(s, s.charID, pkt.CampaignID)
+
+	if err != nil || campaign == nil {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	hr, err := getCharacterHR(s, s.charID)
+
+	if err != nil {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	bf := byteframe.NewByteFrame()
+	bf.WriteUint8(campaign.State(hr))
+	bf.WriteUint8(0)  // Unk
+	bf.WriteUint16(0) // Unk
+
+	doAckSimpleSucceed(s, pkt.AckHandle, bf.Data())
+}
+
+func handleMsgMhfApplyCampaign(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfApplyCampaign)
+
+	campaign, err := GetCampaignByID(s, s.charID, pkt.CampaignID)
+
+	if err != nil || campaign == nil {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	hr, err := getCharacterHR(s, s.charID)
+
+	if err != nil || campaign.State(hr) != CampaignStateOpen {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	if campaign.Code.Valid {
+		code, err := stringsupport.ConvertShiftJISToUTF8(stripNullTerminator(string(pkt.Code)))
+
+		if err != nil || code != campaign.Code.String {
+			doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+			return
+		}
+	}
+
+	err = campaign.Apply(s)
+
+	if err != nil {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	doAckSimpleSucceed(s, pkt.AckHandle, make([]byte, 4))
+}
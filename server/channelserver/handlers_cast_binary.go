@@ -2,11 +2,11 @@ package channelserver
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/Andoryuuta/Erupe/network/binpacket"
 	"github.com/Andoryuuta/Erupe/network/mhfpacket"
 	"github.com/Andoryuuta/byteframe"
+	"go.uber.org/zap"
 )
 
 // MSG_SYS_CAST[ED]_BINARY types enum
@@ -67,6 +67,36 @@ func handleMsgSysCastBinary(s *Session, p mhfpacket.MHFPacket) {
 		realPayload = pkt.RawDataPayload
 	}
 
+	// Handle chat
+	if pkt.MessageType == BinaryMessageTypeChat {
+		bf := byteframe.NewByteFrameFromBytes(realPayload)
+
+		// IMPORTANT! Casted binary objects are sent _as they are in memory_,
+		// this means little endian for LE CPUs, might be different for PS3/PS4/PSP/XBOX.
+		bf.SetLE()
+
+		chatMessage := &binpacket.MsgBinChat{}
+		chatMessage.Parse(bf)
+
+		// Commands are only answered to their sender.
+		if handleChatCommand(s, chatMessage.Message) {
+			return
+		}
+
+		s.logger.Debug(
+			"Got chat message",
+			zap.Uint8("type", uint8(chatMessage.Type)),
+			zap.String("sender", chatMessage.SenderName),
+			zap.String("message", chatMessage.Message),
+		)
+
+		// Discord integration
+		if s.server.erupeConfig.Discord.Enabled {
+			message := fmt.Sprintf("%s: %s", chatMessage.SenderName, chatMessage.Message)
+			s.server.discordSession.ChannelMessageSend(s.server.erupeConfig.Discord.ChannelID, message)
+		}
+	}
+
 	// Make the response to forward to the other client(s).
 	resp := &mhfpacket.MsgSysCastedBinary{
 		CharID:         s.charID,
@@ -75,14 +105,25 @@ func handleMsgSysCastBinary(s *Session, p mhfpacket.MHFPacket) {
 		RawDataPayload: realPayload,
 	}
 
+	// Chat isn't delivered to players who have blocked the sender.
+	var blockers map[uint32]bool
+
+	if pkt.MessageType == BinaryMessageTypeChat {
+		blockers, _ = GetBlockingCharacters(s, s.charID)
+	}
+
 	// Send to the proper recipients.
 	switch pkt.BroadcastType {
 	case BroadcastTypeWorld:
-		s.server.BroadcastMHF(resp, s)
+		s.server.BroadcastMHFExcept(resp, s, blockers)
 	case BroadcastTypeStage:
-		s.stage.BroadcastMHF(resp, s)
+		s.stage.BroadcastMHFExcept(resp, s, blockers)
 	case BroadcastTypeTargeted:
 		for _, targetID := range (*msgBinTargeted).TargetCharIDs {
+			if blockers[targetID] {
+				continue
+			}
+
 			char := s.server.FindSessionByCharID(targetID)
 
 			if char != nil {
@@ -93,54 +134,10 @@ func handleMsgSysCastBinary(s *Session, p mhfpacket.MHFPacket) {
 		s.Lock()
 		haveStage := s.stage != nil
 		if haveStage {
-			s.stage.BroadcastMHF(resp, s)
+			s.stage.BroadcastMHFExcept(resp, s, blockers)
 		}
 		s.Unlock()
 	}
-
-	// Handle chat
-	if pkt.MessageType == BinaryMessageTypeChat {
-		bf := byteframe.NewByteFrameFromBytes(realPayload)
-
-		// IMPORTANT! Casted binary objects are sent _as they are in memory_,
-		// this means little endian for LE CPUs, might be different for PS3/PS4/PSP/XBOX.
-		bf.SetLE()
-
-		chatMessage := &binpacket.MsgBinChat{}
-		chatMessage.Parse(bf)
-
-		fmt.Printf("Got chat message: %+v\n", chatMessage)
-
-		// Discord integration
-		if s.server.erupeConfig.Discord.Enabled {
-			message := fmt.Sprintf("%s: %s", chatMessage.SenderName, chatMessage.Message)
-			s.server.discordSession.ChannelMessageSend(s.server.erupeConfig.Discord.ChannelID, message)
-		}
-
-		if strings.HasPrefix(chatMessage.Message, "!tele ") {
-			var x, y int16
-			n, err := fmt.Sscanf(chatMessage.Message, "!tele %d %d", &x, &y)
-			if err != nil || n != 2 {
-				sendServerChatMessage(s, "Invalid command. Usage:\"!tele 500 500\"")
-			} else {
-				sendServerChatMessage(s, fmt.Sprintf("Teleporting to %d %d", x, y))
-
-				// Make the inside of the casted binary
-				payload := byteframe.NewByteFrame()
-				payload.SetLE()
-				payload.WriteUint8(2) // SetState type(position == 2)
-				payload.WriteInt16(x) // X
-				payload.WriteInt16(y) // Y
-				payloadBytes := payload.Data()
-
-				s.QueueSendMHF(&mhfpacket.MsgSysCastedBinary{
-					CharID:         s.charID,
-					MessageType:    BinaryMessageTypeState,
-					RawDataPayload: payloadBytes,
-				})
-			}
-		}
-	}
 }
 
 func handleMsgSysCastedBinary(s *Session, p mhfpacket.MHFPacket) {}
@@ -0,0 +1,57 @@
+package channelserver
+
+import (
+	"github.com/Andoryuuta/Erupe/network/mhfpacket"
+	"github.com/Andoryuuta/byteframe"
+)
+
+// Size of the padded name in blacklist entries.
+const contactNameSize = 16
+
+// handleMsgMhfListMember lists the blacklist, friends are sent with the sign in response instead.
+func handleMsgMhfListMember(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfListMember)
+
+	blacklist, err := GetContacts(s, s.charID, ContactListBlacklist)
+
+	if err != nil {
+		doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	resp := byteframe.NewByteFrame()
+	resp.WriteUint32(uint32(len(blacklist)))
+
+	for _, contact := range blacklist {
+		resp.WriteUint32(contact.CharID)
+		resp.WriteUint32(contactNameSize)
+		resp.WriteBytes(fixedSizeShiftJIS(contact.Name, contactNameSize))
+	}
+
+	doAckBufSucceed(s, pkt.AckHandle, resp.Data())
+}
+
+func handleMsgMhfOprMember(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfOprMember)
+
+	list := ContactListFriends
+
+	if pkt.Blacklist {
+		list = ContactListBlacklist
+	}
+
+	var err error
+
+	if pkt.Operation {
+		err = RemoveContacts(s, s.charID, list, pkt.CharIDs)
+	} else {
+		err = AddContacts(s, s.charID, list, pkt.CharIDs)
+	}
+
+	if err != nil {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	doAckSimpleSucceed(s, pkt.AckHandle, make([]byte, 4))
+}
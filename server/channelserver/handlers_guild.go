@@ -11,6 +11,27 @@ import (
 	"sort"
 )
 
+// getMemberGuild returns the guild the session character is a full member of, nil if there is none.
+func getMemberGuild(s *Session) (*Guild, *GuildMember, error) {
+	characterGuildData, err := GetCharacterGuildData(s, s.charID)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if characterGuildData == nil || characterGuildData.IsApplicant {
+		return nil, nil, nil
+	}
+
+	guild, err := GetGuildInfoByID(s, characterGuildData.GuildID)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return guild, characterGuildData, nil
+}
+
 func handleMsgMhfCreateGuild(s *Session, p mhfpacket.MHFPacket) {
 	pkt := p.(*mhfpacket.MsgMhfCreateGuild)
 
@@ -69,7 +90,11 @@ func handleMsgMhfOperateGuild(s *Session, p mhfpacket.MHFPacket) {
 
 		bf.WriteUint32(uint32(response))
 	case mhfpacket.OPERATE_GUILD_ACTION_APPLY:
-		err = guild.CreateApplication(s, s.charID, GuildApplicationTypeApplied, nil)
+		if guild.Recruiting {
+			err = guild.CreateApplication(s, s.charID, GuildApplicationTypeApplied, nil)
+		} else {
+			err = fmt.Errorf("guild '%d' is not accepting applications", guild.ID)
+		}
 
 		if err != nil {
 			// All successful acks return 0x01, assuming 0x00 is failure
@@ -78,10 +103,17 @@ func handleMsgMhfOperateGuild(s *Session, p mhfpacket.MHFPacket) {
 			bf.WriteUint32(guild.LeaderCharID)
 		}
 	case mhfpacket.OPERATE_GUILD_ACTION_LEAVE:
+		if characterGuildInfo == nil || characterGuildInfo.GuildID != guild.ID {
+			doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+			return
+		}
+
 		var err error
 
 		if characterGuildInfo.IsApplicant {
 			err = guild.RejectApplication(s, s.charID)
+		} else if characterGuildInfo.IsLeader {
+			err = handleLeaderLeave(s, guild)
 		} else {
 			err = guild.RemoveCharacter(s, s.charID)
 		}
@@ -100,6 +132,20 @@ func handleMsgMhfOperateGuild(s *Session, p mhfpacket.MHFPacket) {
 		if err != nil {
 			return
 		}
+	case mhfpacket.OPERATE_GUILD_SET_APPLICATION_DENY, mhfpacket.OPERATE_GUILD_SET_APPLICATION_ALLOW:
+		if characterGuildInfo == nil || (!characterGuildInfo.IsLeader && !characterGuildInfo.IsSubLeader()) {
+			doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+			return
+		}
+
+		guild.Recruiting = pkt.Action == mhfpacket.OPERATE_GUILD_SET_APPLICATION_ALLOW
+
+		err := guild.Save(s)
+
+		if err != nil {
+			doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+			return
+		}
 	case mhfpacket.OPERATE_GUILD_SET_AVOID_LEADERSHIP_TRUE:
 		handleAvoidLeadershipUpdate(s, pkt, true)
 	case mhfpacket.OPERATE_GUILD_SET_AVOID_LEADERSHIP_FALSE:
@@ -148,13 +194,116 @@ func handleMsgMhfOperateGuild(s *Session, p mhfpacket.MHFPacket) {
 			doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
 			return
 		}
+	case mhfpacket.OPERATE_GUILD_ACTION_RESIGN:
+		if guild.LeaderCharID != s.charID {
+			doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+			return
+		}
+
+		successor, err := guild.Successor(s, false)
+
+		if err == nil && successor != nil {
+			err = guild.TransferLeadership(s, successor.CharID)
+		}
+
+		if err != nil || successor == nil {
+			doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+			return
+		}
+
+		bf.WriteUint32(successor.CharID)
+	case mhfpacket.OPERATE_GUILD_ACTION_RENAME_PUGI_1,
+		mhfpacket.OPERATE_GUILD_ACTION_RENAME_PUGI_2,
+		mhfpacket.OPERATE_GUILD_ACTION_RENAME_PUGI_3,
+		mhfpacket.OPERATE_GUILD_ACTION_CHANGE_PUGI_1,
+		mhfpacket.OPERATE_GUILD_ACTION_CHANGE_PUGI_2,
+		mhfpacket.OPERATE_GUILD_ACTION_CHANGE_PUGI_3,
+		mhfpacket.OPERATE_GUILD_ACTION_UNLOCK_PUGI_OUTFIT:
+		if characterGuildInfo == nil || characterGuildInfo.GuildID != guild.ID ||
+			(!characterGuildInfo.IsLeader && !characterGuildInfo.IsSubLeader()) {
+			s.logger.Warn(fmt.Sprintf("character '%d' is attempting to manage guild '%d' pugi without permission", s.charID, guild.ID))
+			doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+			return
+		}
+
+		err := handleOperateGuildPugi(s, guild, pkt)
+
+		if err != nil {
+			s.logger.Warn("failed to update guild pugi", zap.Error(err), zap.Uint32("guildID", guild.ID))
+			doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+			return
+		}
 	default:
-		panic(fmt.Sprintf("unhandled operate guild action '%d'", pkt.Action))
+		s.logger.Warn("unhandled operate guild action", zap.Uint8("action", uint8(pkt.Action)))
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
 	}
 
 	doAckSimpleSucceed(s, pkt.AckHandle, bf.Data())
 }
 
+// handleOperateGuildPugi renames, dresses or unlocks outfits for the guild hall poogies.
+func handleOperateGuildPugi(s *Session, guild *Guild, pkt *mhfpacket.MsgMhfOperateGuild) error {
+	pbf := byteframe.NewByteFrameFromBytes(pkt.UnkData)
+
+	dataLength := pbf.ReadUint8()
+	value := pbf.ReadUint32()
+
+	var err error
+
+	switch pkt.Action {
+	case mhfpacket.OPERATE_GUILD_ACTION_RENAME_PUGI_1,
+		mhfpacket.OPERATE_GUILD_ACTION_RENAME_PUGI_2,
+		mhfpacket.OPERATE_GUILD_ACTION_RENAME_PUGI_3:
+		var name string
+
+		name, err = stringsupport.ConvertShiftJISToUTF8(stripNullTerminator(string(pbf.ReadBytes(uint(dataLength)))))
+
+		if err != nil {
+			return err
+		}
+
+		err = guild.SetPugiName(int(pkt.Action-mhfpacket.OPERATE_GUILD_ACTION_RENAME_PUGI_1)+1, name)
+	case mhfpacket.OPERATE_GUILD_ACTION_CHANGE_PUGI_1,
+		mhfpacket.OPERATE_GUILD_ACTION_CHANGE_PUGI_2,
+		mhfpacket.OPERATE_GUILD_ACTION_CHANGE_PUGI_3:
+		err = guild.SetPugiOutfit(int(pkt.Action-mhfpacket.OPERATE_GUILD_ACTION_CHANGE_PUGI_1)+1, uint8(value))
+	case mhfpacket.OPERATE_GUILD_ACTION_UNLOCK_PUGI_OUTFIT:
+		err = guild.CanUnlockPugiOutfit(uint8(value))
+
+		if err == nil {
+			err = guild.UnlockPugiOutfit(uint8(value))
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return guild.Save(s)
+}
+
+// handleLeaderLeave hands the guild to the next member before the leader leaves, disbanding it if nobody is left.
+func handleLeaderLeave(s *Session, guild *Guild) error {
+	successor, err := guild.Successor(s, true)
+
+	if err != nil {
+		return err
+	}
+
+	if successor == nil {
+		return guild.Disband(s)
+	}
+
+	err = guild.TransferLeadership(s, successor.CharID)
+
+	if err != nil {
+		return err
+	}
+
+	return guild.RemoveCharacter(s, s.charID)
+}
+
 func handleAvoidLeadershipUpdate(s *Session, pkt *mhfpacket.MsgMhfOperateGuild, avoidLeadership bool) {
 	characterGuildData, err := GetCharacterGuildData(s, s.charID)
 
@@ -246,7 +395,6 @@ func handleMsgMhfOperateGuildMember(s *Session, p mhfpacket.MHFPacket) {
 	if err != nil || guild == nil {
 		doAckSimpleFail(s, pkt.AckHandle, nil)
 		return
-		return
 	}
 
 	actorCharacter, err := GetCharacterGuildData(s, s.charID)
@@ -281,10 +429,16 @@ func handleMsgMhfOperateGuildMember(s *Session, p mhfpacket.MHFPacket) {
 
 	switch pkt.Action {
 	case mhfpacket.OPERATE_GUILD_MEMBER_ACTION_KICK:
+		if pkt.CharID == guild.LeaderCharID {
+			doAckSimpleFail(s, pkt.AckHandle, nil)
+			return
+		}
+
 		err = guild.RemoveCharacter(s, pkt.CharID)
 	default:
+		s.logger.Warn("unhandled operate guild member action", zap.Uint8("action", pkt.Action))
 		doAckSimpleFail(s, pkt.AckHandle, nil)
-		panic(fmt.Sprintf("unhandled operateGuildMember action '%d'", pkt.Action))
+		return
 	}
 
 	if err != nil {
@@ -332,11 +486,8 @@ func handleMsgMhfInfoGuild(s *Session, p mhfpacket.MHFPacket) {
 
 		bf.WriteUint32(guild.ID)
 		bf.WriteUint32(guild.LeaderCharID)
-		// Unk 0x09 = Guild Hall available, maybe guild hall type?
-		// Guild hall available on at least
-		// 0x09 0x08 0x02
-		// Should just be outright guild level for guild hall features, 17 gives everything
-		bf.WriteUint16(guild.GuildHallType)
+		// Guild hall features are unlocked by rank, 17 gives everything
+		bf.WriteUint16(guild.Rank())
 		bf.WriteUint16(guild.MemberCount)
 
 		bf.WriteUint8(guild.MainMotto)
@@ -368,19 +519,13 @@ func handleMsgMhfInfoGuild(s *Session, p mhfpacket.MHFPacket) {
 
 		bf.WriteUint32(guild.RP)
 		bf.WriteBytes([]byte(leaderName))
-		//bf.WriteBytes([]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0x02, 0x00, 0x00, 0x00, 0x00}) // Unk
-		bf.WriteBytes([]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x02, 0x02, 0x00, 0x00, 0x18, 0xBD}) // Level 17 guild's version
-
-		// Pugi's names, probably expected as null until you have them with levels? Null gives them a default japanese name
-		for i := 0; i < 3; i++ {
-			bf.WriteUint8(0x1) // Name Length - 1 minimum due to null byte
-			bf.WriteUint8(0x0) // Name string
-		}
+		bf.WriteUint32(0x00)              // Unk
+		bf.WriteBool(false)               // Unk
+		bf.WriteBool(guild.IsMaxRank())   // Special guild hall, only seen on rank 17 guilds
+		bf.WriteBytes([]byte{0x02, 0x02}) // Unk
+		bf.WriteUint32(0x00)              // Unk, seen as 0x18BD on a rank 17 guild
 
-		// probably guild pugi properties, should be status, stamina and luck outfits
-		bf.WriteBytes([]byte{
-			0x04, 0x02, 0x03, 0x04, 0x02, 0x03, 0x00, 0x00, 0x00, 0x4E,
-		})
+		writeGuildPugi(bf, &guild.GuildPugi)
 
 		// Unk flags
 		bf.WriteUint8(0x3C) // also seen as 0x32 on JP and 0x64 on TW
@@ -389,31 +534,14 @@ func handleMsgMhfInfoGuild(s *Session, p mhfpacket.MHFPacket) {
 			0x00, 0x00, 0xD6, 0xD8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 		})
 
-		bf.WriteUint32(0x00) // Alliance ID
-
-		// TODO add alliance parts here
-		//
-		//if (AllianceID != 0) {
-		//  uint16 AllianceDataUnk;
-		//  uint16 AllianceDataUnk;
-		//  uint16 AllianceNameLength;
-		//	char AllianceName[AllianceNameLength];
-		//
-		//	byte NumAllianceMembers;
-		//
-		//	struct AllianceMember {
-		//		uint32 Unk;
-		//		uint32 Unk;
-		//		uint16 Unk;
-		//		uint16 Unk;
-		//		uint16 Unk;
-		//		uint16 GuildNameLength;
-		//		char GuildName[GuildNameLength];
-		//		uint16 GuildLeaderNameLength;
-		//		char GuildLeaderName[GuildLeaderNameLength];
-		//
-		//	} member[NumAllianceMembers] <optimize=false>;
-		//}
+		alliance, err := GetAllianceByGuildID(s, guild.ID)
+
+		if err != nil {
+			doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
+			return
+		}
+
+		writeGuildAlliance(bf, alliance)
 
 		applicants, err := GetGuildMembers(s, guild.ID, true)
 
@@ -484,23 +612,37 @@ func handleMsgMhfEnumerateGuild(s *Session, p mhfpacket.MHFPacket) {
 	var err error
 
 	switch pkt.Type {
-	case mhfpacket.ENUMERATE_GUILD_TYPE_NAME:
-		// I have no idea if is really little endian, but it seems too weird to have a random static
-		// 0x00 before the string
-		searchTermLength := binary.LittleEndian.Uint16(pkt.RawDataPayload[9:11])
-		searchTerm := pkt.RawDataPayload[11 : 11+searchTermLength]
-
-		var searchTermSafe string
+	case mhfpacket.ENUMERATE_GUILD_TYPE_NAME, mhfpacket.ENUMERATE_GUILD_TYPE_LEADER_NAME:
+		var searchTerm string
 
-		searchTermSafe, err = stringsupport.ConvertShiftJISToUTF8(stripNullTerminator(string(searchTerm)))
+		searchTerm, err = stringsupport.ConvertShiftJISToUTF8(stripNullTerminator(string(pkt.Data2)))
 
 		if err != nil {
-			panic(err)
+			break
 		}
 
-		guilds, err = FindGuildsByName(s, searchTermSafe)
+		if pkt.Type == mhfpacket.ENUMERATE_GUILD_TYPE_NAME {
+			guilds, err = FindGuildsByName(s, searchTerm, pkt.Page)
+		} else {
+			guilds, err = FindGuildsByLeaderName(s, searchTerm, pkt.Page)
+		}
+	case mhfpacket.ENUMERATE_GUILD_TYPE_LEADER_ID:
+		guilds, err = FindGuildsByLeaderID(s, binary.BigEndian.Uint32(pkt.Data1))
+	case mhfpacket.ENUMERATE_GUILD_TYPE_ORDER_MEMBERS:
+		guilds, err = ListGuilds(s, GuildOrderMembers, !pkt.Sorting, false, pkt.Page)
+	case mhfpacket.ENUMERATE_GUILD_TYPE_ORDER_REGISTRATION:
+		guilds, err = ListGuilds(s, GuildOrderCreated, pkt.Sorting, false, pkt.Page)
+	case mhfpacket.ENUMERATE_GUILD_TYPE_ORDER_RANK:
+		guilds, err = ListGuilds(s, GuildOrderRP, !pkt.Sorting, false, pkt.Page)
+	case mhfpacket.ENUMERATE_GUILD_TYPE_MOTTO:
+		mainMotto := uint8(binary.BigEndian.Uint16(pkt.Data1[0:2]))
+		subMotto := uint8(binary.BigEndian.Uint16(pkt.Data1[2:4]))
+		guilds, err = FindGuildsByMotto(s, mainMotto, subMotto, pkt.Page)
+	case mhfpacket.ENUMERATE_GUILD_TYPE_NEW:
+		// Newest guilds still looking for members.
+		guilds, err = ListGuilds(s, GuildOrderCreated, !pkt.Sorting, true, pkt.Page)
 	default:
-		panic(fmt.Sprintf("no handler for guild search type '%d'", pkt.Type))
+		s.logger.Warn("unhandled guild search type", zap.Uint8("type", pkt.Type))
 	}
 
 	if err != nil || guilds == nil {
@@ -519,9 +661,9 @@ func handleMsgMhfEnumerateGuild(s *Session, p mhfpacket.MHFPacket) {
 		bf.WriteUint32(guild.ID)
 		bf.WriteUint32(guild.LeaderCharID)
 		bf.WriteUint16(guild.MemberCount)
-		bf.WriteUint8(0x00)  // Unk
-		bf.WriteUint8(0x00)  // Unk
-		bf.WriteUint16(0x00) // Rank
+		bf.WriteUint8(0x00) // Unk
+		bf.WriteUint8(0x00) // Unk
+		bf.WriteUint16(guild.Rank())
 		bf.WriteUint32(uint32(guild.CreatedAt.Unix()))
 		bf.WriteUint8(uint8(len(guildName)))
 		bf.WriteBytes([]byte(guildName))
@@ -536,7 +678,20 @@ func handleMsgMhfEnumerateGuild(s *Session, p mhfpacket.MHFPacket) {
 	doAckBufSucceed(s, pkt.AckHandle, bf.Data())
 }
 
-func handleMsgMhfUpdateGuild(s *Session, p mhfpacket.MHFPacket) {}
+func handleMsgMhfUpdateGuild(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfUpdateGuild)
+
+	// The packet only carries its ack handle, every guild setting the client can change is sent and applied through
+	// MsgMhfOperateGuild, MsgMhfUpdateGuildIcon or MsgMhfSetGuildManageRight, leaving nothing to apply here.
+	guild, _, err := getMemberGuild(s)
+
+	if err != nil || guild == nil {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	doAckSimpleSucceed(s, pkt.AckHandle, make([]byte, 4))
+}
 
 func handleMsgMhfArrangeGuildMember(s *Session, p mhfpacket.MHFPacket) {
 	pkt := p.(*mhfpacket.MsgMhfArrangeGuildMember)
@@ -625,7 +780,7 @@ func handleMsgMhfEnumerateGuildMember(s *Session, p mhfpacket.MHFPacket) {
 	}
 
 	for _, member := range guildMembers {
-		bf.WriteUint32(member.LastLogin)
+		bf.WriteUint32(member.LastActive)
 	}
 
 	bf.WriteBytes([]byte{0x00, 0x00}) // Unk, might be to do with alliance, 0x00 == no alliance
@@ -663,13 +818,40 @@ func handleMsgMhfGetGuildManageRight(s *Session, p mhfpacket.MHFPacket) {
 
 	for _, member := range members {
 		bf.WriteUint32(member.CharID)
-		bf.WriteUint32(0x0)
+		bf.WriteBool(member.Recruiter)
+		bf.WriteBytes(make([]byte, 3))
 	}
 
 	doAckBufSucceed(s, pkt.AckHandle, bf.Data())
 }
 
-func handleMsgMhfSetGuildManageRight(s *Session, p mhfpacket.MHFPacket) {}
+func handleMsgMhfSetGuildManageRight(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfSetGuildManageRight)
+
+	actor, err := GetCharacterGuildData(s, s.charID)
+
+	if err != nil || actor == nil || !actor.IsLeader {
+		s.logger.Warn(fmt.Sprintf("character '%d' is attempting to set guild rights without permission", s.charID))
+		doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	member, err := GetCharacterGuildData(s, pkt.CharID)
+
+	if err != nil || member == nil || member.IsApplicant || member.GuildID != actor.GuildID {
+		doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	err = member.SetRecruiter(s, pkt.Allowed)
+
+	if err != nil {
+		doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	doAckBufSucceed(s, pkt.AckHandle, make([]byte, 4))
+}
 
 func handleMsgMhfGetUdGuildMapInfo(s *Session, p mhfpacket.MHFPacket) {
 	pkt := p.(*mhfpacket.MsgMhfGetUdGuildMapInfo)
@@ -713,12 +895,87 @@ func handleMsgMhfGetGuildTargetMemberNum(s *Session, p mhfpacket.MHFPacket) {
 func handleMsgMhfEnumerateGuildItem(s *Session, p mhfpacket.MHFPacket) {
 	pkt := p.(*mhfpacket.MsgMhfEnumerateGuildItem)
 
-	data, _ := hex.DecodeString("000100004cfa00010017000300000000")
+	characterInfo, err := GetCharacterGuildData(s, s.charID)
 
-	doAckBufSucceed(s, pkt.AckHandle, data)
+	if err != nil || characterInfo == nil || characterInfo.IsApplicant {
+		doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	guild, err := GetGuildInfoByID(s, characterInfo.GuildID)
+
+	if err != nil || guild == nil {
+		doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	items, err := guild.Items(s)
+
+	if err != nil {
+		doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	seen := make(map[uint32]uint16, len(items))
+	bf := byteframe.NewByteFrame()
+	bf.WriteUint16(uint16(len(items)))
+
+	for _, item := range items {
+		seen[item.WarehouseID] = item.Quantity
+		item.Write(bf)
+	}
+
+	s.Lock()
+	s.guildItemsSeen = seen
+	s.Unlock()
+
+	bf.WriteUint16(0) // Unk
+
+	doAckBufSucceed(s, pkt.AckHandle, bf.Data())
 }
 
-func handleMsgMhfUpdateGuildItem(s *Session, p mhfpacket.MHFPacket) {}
+func handleMsgMhfUpdateGuildItem(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfUpdateGuildItem)
+
+	characterInfo, err := GetCharacterGuildData(s, s.charID)
+
+	if err != nil || characterInfo == nil || characterInfo.IsApplicant || characterInfo.GuildID != pkt.GuildID {
+		s.logger.Warn(
+			"character outside of guild attempting to update guild items",
+			zap.Uint32("guildID", pkt.GuildID),
+			zap.Uint32("charID", s.charID),
+		)
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	guild, err := GetGuildInfoByID(s, pkt.GuildID)
+
+	if err != nil || guild == nil {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	s.Lock()
+	seen := s.guildItemsSeen
+	s.Unlock()
+
+	err = guild.UpdateItems(s, s.charID, seen, pkt.UpdatedItems)
+
+	if err != nil {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	// The client now shows the quantities it sent
+	s.Lock()
+	for _, change := range pkt.UpdatedItems {
+		s.guildItemsSeen[change.WarehouseID] = change.Quantity
+	}
+	s.Unlock()
+
+	doAckSimpleSucceed(s, pkt.AckHandle, make([]byte, 4))
+}
 
 func handleMsgMhfUpdateGuildIcon(s *Session, p mhfpacket.MHFPacket) {
 	pkt := p.(*mhfpacket.MsgMhfUpdateGuildIcon)
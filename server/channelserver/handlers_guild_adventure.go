@@ -0,0 +1,108 @@
+package channelserver
+
+import (
+	"github.com/Andoryuuta/Erupe/network/mhfpacket"
+	"github.com/Andoryuuta/byteframe"
+)
+
+func handleMsgMhfLoadGuildAdventure(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfLoadGuildAdventure)
+
+	var adventures []*GuildAdventure
+
+	guild, _, err := getMemberGuild(s)
+
+	if err == nil && guild != nil {
+		adventures, err = guild.Adventures(s, s.charID)
+	}
+
+	if err != nil {
+		doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	bf := byteframe.NewByteFrame()
+	bf.WriteUint8(uint8(len(adventures)))
+
+	for _, adventure := range adventures {
+		bf.WriteUint32(adventure.ID)
+		bf.WriteUint32(adventure.Destination)
+		bf.WriteUint32(adventure.Charge)
+		bf.WriteUint32(uint32(adventure.Depart.Unix()))
+		bf.WriteUint32(uint32(adventure.Return.Unix()))
+		bf.WriteBool(adventure.Collected)
+	}
+
+	doAckBufSucceed(s, pkt.AckHandle, bf.Data())
+}
+
+func handleMsgMhfRegistGuildAdventure(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfRegistGuildAdventure)
+
+	guild, _, err := getMemberGuild(s)
+
+	if err != nil || guild == nil {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	err = guild.RegisterAdventure(s, pkt.Destination)
+
+	if err != nil {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	doAckSimpleSucceed(s, pkt.AckHandle, make([]byte, 4))
+}
+
+func handleMsgMhfAcquireGuildAdventure(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfAcquireGuildAdventure)
+
+	adventure, err := getMemberGuildAdventure(s, pkt.AdventureID)
+
+	if err != nil || adventure == nil {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	err = adventure.Acquire(s, s.charID)
+
+	if err != nil {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	doAckSimpleSucceed(s, pkt.AckHandle, make([]byte, 4))
+}
+
+func handleMsgMhfChargeGuildAdventure(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfChargeGuildAdventure)
+
+	adventure, err := getMemberGuildAdventure(s, pkt.AdventureID)
+
+	if err != nil || adventure == nil {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	err = adventure.AddCharge(s, pkt.Amount)
+
+	if err != nil {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	doAckSimpleSucceed(s, pkt.AckHandle, make([]byte, 4))
+}
+
+// getMemberGuildAdventure returns the adventure if it belongs to the session character's guild.
+func getMemberGuildAdventure(s *Session, adventureID uint32) (*GuildAdventure, error) {
+	guild, _, err := getMemberGuild(s)
+
+	if err != nil || guild == nil {
+		return nil, err
+	}
+
+	return guild.GetAdventure(s, s.charID, adventureID)
+}
@@ -0,0 +1,179 @@
+package channelserver
+
+import (
+	"fmt"
+
+	"github.com/Andoryuuta/Erupe/common/stringsupport"
+	"github.com/Andoryuuta/Erupe/network/mhfpacket"
+	"github.com/Andoryuuta/byteframe"
+	"go.uber.org/zap"
+)
+
+// getLedGuildData returns the session character's membership if they lead the given guild, nil otherwise.
+func getLedGuildData(s *Session, guildID uint32) (*GuildMember, error) {
+	characterGuildData, err := GetCharacterGuildData(s, s.charID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if characterGuildData == nil || characterGuildData.IsApplicant ||
+		!characterGuildData.IsLeader || characterGuildData.GuildID != guildID {
+		return nil, nil
+	}
+
+	return characterGuildData, nil
+}
+
+func handleMsgMhfCreateJoint(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfCreateJoint)
+
+	characterGuildData, err := getLedGuildData(s, pkt.GuildID)
+
+	if err != nil || characterGuildData == nil {
+		s.logger.Warn(fmt.Sprintf("character '%d' is attempting to create an alliance for guild '%d' without permission", s.charID, pkt.GuildID))
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	name, err := stringsupport.ConvertShiftJISToUTF8(stripNullTerminator(pkt.Name))
+
+	if err != nil {
+		s.logger.Warn("failed to convert alliance name to UTF8", zap.Error(err))
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	allianceID, err := CreateAlliance(s, pkt.GuildID, name)
+
+	if err != nil {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	bf := byteframe.NewByteFrame()
+	bf.WriteUint32(allianceID)
+
+	doAckSimpleSucceed(s, pkt.AckHandle, bf.Data())
+}
+
+func handleMsgMhfOperateJoint(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfOperateJoint)
+
+	alliance, err := GetAllianceByID(s, pkt.AllianceID)
+
+	if err != nil || alliance == nil {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	characterGuildData, err := getLedGuildData(s, pkt.GuildID)
+
+	if err != nil || characterGuildData == nil {
+		s.logger.Warn(fmt.Sprintf("character '%d' is attempting to manage alliance '%d' without permission", s.charID, alliance.ID))
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	// Inviting and kicking are followed by the target guild's ID.
+	if (pkt.Action == mhfpacket.OPERATE_JOINT_INVITE || pkt.Action == mhfpacket.OPERATE_JOINT_KICK) && len(pkt.UnkData) < 4 {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	isParent := alliance.ParentGuildID == pkt.GuildID
+	pbf := byteframe.NewByteFrameFromBytes(pkt.UnkData)
+
+	switch pkt.Action {
+	case mhfpacket.OPERATE_JOINT_DISBAND:
+		if !isParent {
+			err = fmt.Errorf("guild '%d' is not the parent of alliance '%d'", pkt.GuildID, alliance.ID)
+			break
+		}
+
+		err = alliance.Disband(s)
+	case mhfpacket.OPERATE_JOINT_INVITE:
+		if !isParent {
+			err = fmt.Errorf("guild '%d' is not the parent of alliance '%d'", pkt.GuildID, alliance.ID)
+			break
+		}
+
+		err = alliance.Invite(s, pbf.ReadUint32())
+	case mhfpacket.OPERATE_JOINT_LEAVE:
+		if !alliance.HasGuild(pkt.GuildID) {
+			err = fmt.Errorf("guild '%d' is not in alliance '%d'", pkt.GuildID, alliance.ID)
+			break
+		}
+
+		err = alliance.RemoveGuild(s, pkt.GuildID)
+	case mhfpacket.OPERATE_JOINT_ACCEPT:
+		err = alliance.AcceptInvitation(s, pkt.GuildID)
+	case mhfpacket.OPERATE_JOINT_DECLINE:
+		err = alliance.DeclineInvitation(s, pkt.GuildID)
+	case mhfpacket.OPERATE_JOINT_KICK:
+		if !isParent {
+			err = fmt.Errorf("guild '%d' is not the parent of alliance '%d'", pkt.GuildID, alliance.ID)
+			break
+		}
+
+		err = alliance.RemoveGuild(s, pbf.ReadUint32())
+	default:
+		err = fmt.Errorf("unhandled operate joint action '%d'", pkt.Action)
+	}
+
+	if err != nil {
+		s.logger.Warn("failed to operate alliance", zap.Error(err), zap.Uint32("allianceID", alliance.ID))
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	doAckSimpleSucceed(s, pkt.AckHandle, make([]byte, 4))
+}
+
+func handleMsgMhfInfoJoint(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfInfoJoint)
+
+	alliance, err := GetAllianceByID(s, pkt.AllianceID)
+
+	if err != nil || alliance == nil {
+		doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	bf := byteframe.NewByteFrame()
+	writeGuildAlliance(bf, alliance)
+
+	doAckBufSucceed(s, pkt.AckHandle, bf.Data())
+}
+
+// writeGuildAlliance writes the alliance section shared by guild and alliance info responses.
+func writeGuildAlliance(bf *byteframe.ByteFrame, alliance *GuildAlliance) {
+	if alliance == nil {
+		bf.WriteUint32(0x00) // Alliance ID
+		return
+	}
+
+	allianceName := stringsupport.MustConvertUTF8ToShiftJIS(alliance.Name) + "\x00"
+
+	bf.WriteUint32(alliance.ID)
+	bf.WriteUint16(0x00) // Unk
+	bf.WriteUint16(0x00) // Unk
+	bf.WriteUint16(uint16(len(allianceName)))
+	bf.WriteBytes([]byte(allianceName))
+	bf.WriteUint8(uint8(len(alliance.Guilds)))
+
+	for _, guild := range alliance.Guilds {
+		guildName := stringsupport.MustConvertUTF8ToShiftJIS(guild.Name) + "\x00"
+		leaderName := stringsupport.MustConvertUTF8ToShiftJIS(guild.LeaderName) + "\x00"
+
+		bf.WriteUint32(guild.ID)
+		bf.WriteUint32(guild.LeaderCharID)
+		bf.WriteUint16(guild.Rank())
+		bf.WriteUint16(guild.MemberCount)
+		bf.WriteUint16(0x00) // Unk
+		bf.WriteUint16(uint16(len(guildName)))
+		bf.WriteBytes([]byte(guildName))
+		bf.WriteUint16(uint16(len(leaderName)))
+		bf.WriteBytes([]byte(leaderName))
+	}
+}
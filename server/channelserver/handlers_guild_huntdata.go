@@ -0,0 +1,56 @@
+package channelserver
+
+import (
+	"time"
+
+	"github.com/Andoryuuta/Erupe/network/mhfpacket"
+	"github.com/Andoryuuta/byteframe"
+	"go.uber.org/zap"
+)
+
+func handleMsgMhfGuildHuntdata(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfGuildHuntdata)
+
+	guild, characterGuildData, err := getMemberGuild(s)
+
+	if err != nil || guild == nil {
+		doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	bf := byteframe.NewByteFrame()
+
+	switch pkt.Operation {
+	case mhfpacket.GUILD_HUNTDATA_ACQUIRE:
+		err = characterGuildData.ClaimHuntdata(s)
+	case mhfpacket.GUILD_HUNTDATA_ENUMERATE:
+		var kills []*GuildMonsterKills
+
+		kills, err = guild.WeeklyKills(s, guildWeekStart(time.Now()))
+
+		if err != nil {
+			break
+		}
+
+		bf.WriteUint8(uint8(len(kills)))
+
+		for _, k := range kills {
+			bf.WriteUint32(k.MonsterID)
+			bf.WriteUint32(k.Kills)
+		}
+	case mhfpacket.GUILD_HUNTDATA_CHECK:
+		var unclaimed bool
+
+		unclaimed, err = characterGuildData.HasUnclaimedHuntdata(s)
+		bf.WriteBool(unclaimed)
+	default:
+		s.logger.Warn("unhandled guild huntdata operation", zap.Uint8("operation", uint8(pkt.Operation)))
+	}
+
+	if err != nil {
+		doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	doAckBufSucceed(s, pkt.AckHandle, bf.Data())
+}
@@ -0,0 +1,63 @@
+package channelserver
+
+import (
+	"github.com/Andoryuuta/Erupe/network/mhfpacket"
+	"github.com/Andoryuuta/byteframe"
+)
+
+func writeGuildMeal(bf *byteframe.ByteFrame, meal *GuildMeal) {
+	bf.WriteUint32(meal.ID)
+	bf.WriteUint32(uint32(meal.MealID))
+	bf.WriteUint32(uint32(meal.Level))
+	bf.WriteUint32(uint32(meal.ExpiresAt().Unix()))
+}
+
+func handleMsgMhfLoadGuildCooking(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfLoadGuildCooking)
+
+	var meals []*GuildMeal
+
+	guild, _, err := getMemberGuild(s)
+
+	if err == nil && guild != nil {
+		meals, err = guild.Meals(s)
+	}
+
+	if err != nil {
+		doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	bf := byteframe.NewByteFrame()
+	bf.WriteUint16(uint16(len(meals)))
+
+	for _, meal := range meals {
+		writeGuildMeal(bf, meal)
+	}
+
+	doAckBufSucceed(s, pkt.AckHandle, bf.Data())
+}
+
+func handleMsgMhfRegistGuildCooking(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfRegistGuildCooking)
+
+	guild, _, err := getMemberGuild(s)
+
+	if err != nil || guild == nil {
+		doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	meal, err := guild.CookMeal(s, s.charID, pkt.OverwriteID, pkt.MealID, pkt.Success)
+
+	if err != nil {
+		doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	bf := byteframe.NewByteFrame()
+	bf.WriteUint16(1)
+	writeGuildMeal(bf, meal)
+
+	doAckBufSucceed(s, pkt.AckHandle, bf.Data())
+}
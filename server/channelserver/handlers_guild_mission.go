@@ -0,0 +1,107 @@
+package channelserver
+
+import (
+	"github.com/Andoryuuta/Erupe/network/mhfpacket"
+	"github.com/Andoryuuta/byteframe"
+	"go.uber.org/zap"
+)
+
+func handleMsgMhfGetGuildMissionList(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfGetGuildMissionList)
+
+	missions, err := GetGuildMissions(s)
+
+	if err != nil {
+		doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	bf := byteframe.NewByteFrame()
+	writeGuildMissions(bf, missions)
+	bf.WriteBytes(make([]byte, 20)) // Unk
+
+	doAckBufSucceed(s, pkt.AckHandle, bf.Data())
+}
+
+func handleMsgMhfGetGuildMissionRecord(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfGetGuildMissionRecord)
+
+	var missions []*GuildMission
+
+	guild, _, err := getMemberGuild(s)
+
+	if err == nil && guild != nil {
+		missions, err = guild.Missions(s)
+	}
+
+	if err != nil {
+		doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	bf := byteframe.NewByteFrame()
+	writeGuildMissions(bf, missions)
+
+	doAckBufSucceed(s, pkt.AckHandle, bf.Data())
+}
+
+func handleMsgMhfAddGuildMissionCount(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfAddGuildMissionCount)
+
+	guild, _, err := getMemberGuild(s)
+
+	if err != nil || guild == nil {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	err = guild.AddMissionCount(s, pkt.MissionID, pkt.Count)
+
+	if err != nil {
+		s.logger.Warn("failed to add guild mission count", zap.Error(err), zap.Uint32("guildID", guild.ID))
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	doAckSimpleSucceed(s, pkt.AckHandle, make([]byte, 4))
+}
+
+func handleMsgMhfSetGuildMissionTarget(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfSetGuildMissionTarget)
+
+	guild, characterGuildData, err := getMemberGuild(s)
+
+	if err != nil || guild == nil || (!characterGuildData.IsLeader && !characterGuildData.IsSubLeader()) {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	err = guild.SetMissionTarget(s, pkt.MissionID)
+
+	if err != nil {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	doAckSimpleSucceed(s, pkt.AckHandle, make([]byte, 4))
+}
+
+func handleMsgMhfCancelGuildMissionTarget(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfCancelGuildMissionTarget)
+
+	guild, characterGuildData, err := getMemberGuild(s)
+
+	if err != nil || guild == nil || (!characterGuildData.IsLeader && !characterGuildData.IsSubLeader()) {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	err = guild.CancelMissionTarget(s, pkt.MissionID)
+
+	if err != nil {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	doAckSimpleSucceed(s, pkt.AckHandle, make([]byte, 4))
+}
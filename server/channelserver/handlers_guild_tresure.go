@@ -1,23 +1,193 @@
 package channelserver
 
-import "github.com/Andoryuuta/Erupe/network/mhfpacket"
+import (
+	"github.com/Andoryuuta/Erupe/common/stringsupport"
+	"github.com/Andoryuuta/Erupe/network/mhfpacket"
+	"github.com/Andoryuuta/byteframe"
+	"go.uber.org/zap"
+)
 
 func handleMsgMhfEnumerateGuildTresure(s *Session, p mhfpacket.MHFPacket) {
 	pkt := p.(*mhfpacket.MsgMhfEnumerateGuildTresure)
 
-	doAckBufSucceed(s, pkt.AckHandle, make([]byte, 4))
+	hunts := make([]*GuildTreasureHunt, 0)
+
+	guild, _, err := getMemberGuild(s)
+
+	if err == nil && guild != nil {
+		// The client asks for a single hunt when looking for the one the character is on.
+		if pkt.MaxHunts == 1 {
+			var hunt *GuildTreasureHunt
+			hunt, err = GetActiveTreasureHunt(s, s.charID)
+
+			if hunt != nil {
+				hunts = append(hunts, hunt)
+			}
+		} else {
+			hunts, err = guild.TreasureHunts(s, s.charID)
+		}
+	}
+
+	if err != nil {
+		doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	if pkt.MaxHunts > 0 && len(hunts) > int(pkt.MaxHunts) {
+		hunts = hunts[:pkt.MaxHunts]
+	}
+
+	bf := byteframe.NewByteFrame()
+	bf.WriteUint16(uint16(len(hunts)))
+	bf.WriteUint16(uint16(len(hunts)))
+
+	for _, hunt := range hunts {
+		hostName := stringsupport.MustConvertUTF8ToShiftJIS(hunt.HostName) + "\x00"
+
+		bf.WriteUint32(hunt.ID)
+		bf.WriteUint32(hunt.Destination)
+		bf.WriteUint32(hunt.Level)
+		bf.WriteUint32(hunt.Hunters)
+		bf.WriteUint32(uint32(hunt.CreatedAt.Unix()))
+		bf.WriteBool(hunt.Acquired)
+		bf.WriteBool(hunt.CompletedAt.Valid)
+		bf.WriteUint8(uint8(len(hostName)))
+		bf.WriteBytes([]byte(hostName))
+		bf.WriteUint16(uint16(len(hunt.HuntData)))
+		bf.WriteBytes(hunt.HuntData)
+	}
+
+	doAckBufSucceed(s, pkt.AckHandle, bf.Data())
+}
+
+func handleMsgMhfRegistGuildTresure(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfRegistGuildTresure)
+
+	guild, _, err := getMemberGuild(s)
+
+	if err != nil || guild == nil || len(pkt.Data) < 8 {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	pbf := byteframe.NewByteFrameFromBytes(pkt.Data)
+	destination := pbf.ReadUint32()
+	level := pbf.ReadUint32()
+
+	huntID, err := guild.RegisterTreasureHunt(s, s.charID, destination, level, pbf.DataFromCurrent())
+
+	if err != nil {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	bf := byteframe.NewByteFrame()
+	bf.WriteUint32(huntID)
+
+	doAckSimpleSucceed(s, pkt.AckHandle, bf.Data())
+}
+
+func handleMsgMhfAcquireGuildTresure(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfAcquireGuildTresure)
+
+	guild, _, err := getMemberGuild(s)
+
+	if err != nil || guild == nil {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	hunt, err := GetTreasureHunt(s, s.charID, pkt.HuntID)
+
+	if err != nil || hunt == nil || hunt.GuildID != guild.ID || !hunt.IsOpen() {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	activeHunt, err := GetActiveTreasureHunt(s, s.charID)
+
+	if err != nil || (activeHunt != nil && activeHunt.ID != hunt.ID) {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	err = hunt.Join(s, s.charID)
+
+	if err != nil {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	doAckSimpleSucceed(s, pkt.AckHandle, make([]byte, 4))
 }
 
-func handleMsgMhfRegistGuildTresure(s *Session, p mhfpacket.MHFPacket) {}
+func handleMsgMhfOperateGuildTresureReport(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfOperateGuildTresureReport)
+
+	hunt, err := GetTreasureHunt(s, s.charID, pkt.HuntID)
+
+	if err != nil || hunt == nil || !hunt.Acquired {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	switch pkt.State {
+	case mhfpacket.GUILD_TRESURE_REPORT_CANCEL:
+		err = hunt.Leave(s, s.charID)
+	case mhfpacket.GUILD_TRESURE_REPORT_COMPLETE:
+		if !hunt.IsOpen() {
+			doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+			return
+		}
 
-func handleMsgMhfAcquireGuildTresure(s *Session, p mhfpacket.MHFPacket) {}
+		err = hunt.Complete(s)
+	case mhfpacket.GUILD_TRESURE_REPORT_CLAIM:
+		err = ClaimTreasureHuntSouvenirs(s, s.charID, hunt.ID)
+	default:
+		s.logger.Warn("unhandled treasure hunt report state", zap.Uint16("state", pkt.State))
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
 
-func handleMsgMhfOperateGuildTresureReport(s *Session, p mhfpacket.MHFPacket) {}
+	if err != nil {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	doAckSimpleSucceed(s, pkt.AckHandle, make([]byte, 4))
+}
 
 func handleMsgMhfGetGuildTresureSouvenir(s *Session, p mhfpacket.MHFPacket) {
 	pkt := p.(*mhfpacket.MsgMhfGetGuildTresureSouvenir)
 
-	doAckBufSucceed(s, pkt.AckHandle, make([]byte, 6))
+	hunts, err := GetTreasureHuntSouvenirs(s, s.charID)
+
+	if err != nil {
+		doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	bf := byteframe.NewByteFrame()
+	bf.WriteUint32(0x00) // Unk
+	bf.WriteUint16(uint16(len(hunts)))
+
+	for _, hunt := range hunts {
+		bf.WriteUint32(hunt.ID)
+		bf.WriteUint32(hunt.Destination)
+	}
+
+	doAckBufSucceed(s, pkt.AckHandle, bf.Data())
 }
 
-func handleMsgMhfAcquireGuildTresureSouvenir(s *Session, p mhfpacket.MHFPacket) {}
+func handleMsgMhfAcquireGuildTresureSouvenir(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfAcquireGuildTresureSouvenir)
+
+	err := ClaimTreasureHuntSouvenirs(s, s.charID)
+
+	if err != nil {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	doAckSimpleSucceed(s, pkt.AckHandle, make([]byte, 4))
+}
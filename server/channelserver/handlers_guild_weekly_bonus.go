@@ -0,0 +1,85 @@
+package channelserver
+
+import (
+	"time"
+
+	"github.com/Andoryuuta/Erupe/network/mhfpacket"
+	"github.com/Andoryuuta/byteframe"
+)
+
+func handleMsgMhfGetGuildWeeklyBonusMaster(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfGetGuildWeeklyBonusMaster)
+
+	bf := byteframe.NewByteFrame()
+	writeGuildWeeklyBonusTiers(bf)
+
+	doAckBufSucceed(s, pkt.AckHandle, bf.Data())
+}
+
+func handleMsgMhfGetGuildWeeklyBonusActiveCount(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfGetGuildWeeklyBonusActiveCount)
+
+	guild, _, err := getMemberGuild(s)
+
+	if err != nil || guild == nil {
+		doAckBufSucceed(s, pkt.AckHandle, make([]byte, 3))
+		return
+	}
+
+	weekStart := guildWeekStart(time.Now())
+
+	lastWeek, err := guild.WeeklyActivity(s, weekStart.AddDate(0, 0, -7))
+
+	if err != nil {
+		doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	thisWeek, err := guild.WeeklyActivity(s, weekStart)
+
+	if err != nil {
+		doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	bf := byteframe.NewByteFrame()
+	bf.WriteUint8(clampActiveCount(lastWeek.Count())) // Decides this week's bonus
+	bf.WriteUint8(clampActiveCount(thisWeek.Count())) // Progress towards next week's bonus
+	bf.WriteUint8(clampActiveCount(thisWeek.ExceptionalUsers))
+
+	doAckBufSucceed(s, pkt.AckHandle, bf.Data())
+}
+
+func handleMsgMhfAddGuildWeeklyBonusExceptionalUser(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfAddGuildWeeklyBonusExceptionalUser)
+
+	guild, _, err := getMemberGuild(s)
+
+	if err != nil || guild == nil {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	// The client reports a number of users, only the sender is counted so repeated reports can't inflate the bonus.
+	if pkt.NumUsers == 0 {
+		doAckSimpleSucceed(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	err = guild.AddExceptionalUser(s, s.charID)
+
+	if err != nil {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	doAckSimpleSucceed(s, pkt.AckHandle, make([]byte, 4))
+}
+
+func clampActiveCount(count uint32) uint8 {
+	if count > 0xFF {
+		return 0xFF
+	}
+
+	return uint8(count)
+}
@@ -0,0 +1,180 @@
+package channelserver
+
+import (
+	"github.com/Andoryuuta/Erupe/common/stringsupport"
+	"github.com/Andoryuuta/Erupe/network/mhfpacket"
+	"github.com/Andoryuuta/byteframe"
+)
+
+const (
+	EnumerateHouseFriends = iota + 1
+	EnumerateHouseGuild
+	EnumerateHouseName
+	EnumerateHouseCharID
+	EnumerateHouseRecentVisitors
+)
+
+func handleMsgMhfUpdateInterior(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfUpdateInterior)
+
+	house := &House{
+		CharID:    s.charID,
+		Furniture: pkt.InteriorData,
+	}
+
+	err := house.SaveFurniture(s)
+
+	if err != nil {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	doAckSimpleSucceed(s, pkt.AckHandle, make([]byte, 4))
+}
+
+func handleMsgMhfEnumerateHouse(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfEnumerateHouse)
+
+	houses := make([]*House, 0)
+	var err error
+
+	switch pkt.Method {
+	case EnumerateHouseFriends:
+		houses, err = GetFriendHouses(s, s.charID)
+	case EnumerateHouseGuild:
+		guild, guildErr := GetGuildInfoByCharacterId(s, s.charID)
+
+		if guildErr == nil && guild != nil {
+			houses, err = GetGuildHouses(s, guild.ID)
+		}
+	case EnumerateHouseName:
+		var name string
+		name, err = stringsupport.ConvertShiftJISToUTF8(stripNullTerminator(pkt.Name))
+
+		if err == nil {
+			houses, err = SearchHouses(s, name)
+		}
+	case EnumerateHouseCharID:
+		var house *House
+		house, err = GetHouse(s, pkt.CharID)
+
+		if house != nil {
+			houses = append(houses, house)
+		}
+	}
+
+	if err != nil {
+		doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	bf := byteframe.NewByteFrame()
+	bf.WriteUint16(uint16(len(houses)))
+
+	for _, house := range houses {
+		name := stringsupport.MustConvertUTF8ToShiftJIS(house.Name) + "\x00"
+
+		bf.WriteUint32(house.CharID)
+		bf.WriteUint8(house.State)
+		bf.WriteBool(house.HasPassword())
+		bf.WriteUint16(house.HR)
+		bf.WriteUint16(house.GR)
+		bf.WriteBytes(fixedSize(house.Tier, CharacterSaveHouseTierSize))
+		bf.WriteUint8(uint8(len(name)))
+		bf.WriteBytes([]byte(name))
+	}
+
+	doAckBufSucceed(s, pkt.AckHandle, bf.Data())
+}
+
+func handleMsgMhfUpdateHouse(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfUpdateHouse)
+
+	password, err := stringsupport.ConvertShiftJISToUTF8(stripNullTerminator(pkt.Password))
+
+	if err != nil || pkt.State < HouseStateEveryone || pkt.State > HouseStateGuild {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	house := &House{
+		CharID: s.charID,
+		State:  pkt.State,
+	}
+
+	err = house.SetPassword(password)
+
+	if err == nil {
+		err = house.SaveAccess(s)
+	}
+
+	if err != nil {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	doAckSimpleSucceed(s, pkt.AckHandle, make([]byte, 4))
+}
+
+func handleMsgMhfLoadHouse(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfLoadHouse)
+
+	house, err := GetHouse(s, pkt.CharID)
+
+	if err != nil || house == nil {
+		doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	password, err := stringsupport.ConvertShiftJISToUTF8(stripNullTerminator(string(pkt.Password)))
+
+	if err != nil || !house.CanVisit(s, password) {
+		doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	// Remembered so the house stage can be entered after loading it.
+	s.Lock()
+	s.housePasswords[house.CharID] = password
+	s.Unlock()
+
+	bf := byteframe.NewByteFrame()
+
+	if pkt.Destination == 3 {
+		bf.WriteBytes(fixedSize(house.Tier, CharacterSaveHouseTierSize))
+		bf.WriteBytes(fixedSize(house.Data, CharacterSaveHouseDataSize))
+		bf.WriteBytes(make([]byte, 19)) // Unk
+	}
+
+	bf.WriteBytes(fixedSize(house.Furniture, houseInteriorSize))
+
+	doAckBufSucceed(s, pkt.AckHandle, bf.Data())
+}
+
+func handleMsgMhfGetMyhouseInfo(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfGetMyhouseInfo)
+
+	var data []byte
+	err := s.server.db.QueryRow("SELECT house_info FROM characters WHERE id = $1", s.charID).Scan(&data)
+
+	if err != nil {
+		doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	// The first byte is set once the house has been saved, zeroes make the client show its first visit pop up
+	doAckBufSucceed(s, pkt.AckHandle, fixedSize(data, 0x16A))
+}
+
+func handleMsgMhfUpdateMyhouseInfo(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfUpdateMyhouseInfo)
+
+	_, err := s.server.db.Exec("UPDATE characters SET house_info = $1 WHERE id = $2", pkt.Data, s.charID)
+
+	if err != nil {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	doAckSimpleSucceed(s, pkt.AckHandle, make([]byte, 4))
+}
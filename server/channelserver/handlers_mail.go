@@ -1,28 +1,176 @@
 package channelserver
 
 import (
+	"fmt"
+
 	"github.com/Andoryuuta/Erupe/common/stringsupport"
 	"github.com/Andoryuuta/Erupe/network/mhfpacket"
 	"github.com/Andoryuuta/byteframe"
+	"go.uber.org/zap"
 )
 
-func handleMsgMhfSendMail(s *Session, p mhfpacket.MHFPacket) {}
+func handleMsgMhfSendMail(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfSendMail)
 
-func handleMsgMhfReadMail(s *Session, p mhfpacket.MHFPacket) {
-	pkt := p.(*mhfpacket.MsgMhfReadMail)
+	subject, err := stringsupport.ConvertShiftJISToUTF8(stripNullTerminator(string(pkt.Subject)))
 
-	mailId := s.mailList[pkt.AccIndex]
+	if err != nil {
+		s.logger.Warn("failed to convert mail subject to UTF8", zap.Error(err))
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
 
-	if mailId == 0 {
-		doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
-		panic("attempting to read mail that doesn't exist in session map")
+	body, err := stringsupport.ConvertShiftJISToUTF8(stripNullTerminator(string(pkt.Body)))
+
+	if err != nil {
+		s.logger.Warn("failed to convert mail body to UTF8", zap.Error(err))
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	var recipientIDs []uint32
+
+	if pkt.RecipientID == 0 {
+		recipientIDs, err = getGuildMailRecipients(s)
+
+		// Items can't be split between guild members
+		if err == nil && pkt.ItemID != 0 {
+			err = fmt.Errorf("character '%d' is attempting to attach an item to guild mail", s.charID)
+		}
+	} else {
+		recipientIDs = []uint32{pkt.RecipientID}
+	}
+
+	// The server doesn't track inventories, the client takes the item out of the sender's pouch and saves it with
+	// the rest of their save data, so only the amount can be checked here.
+	if err == nil && pkt.ItemID != 0 && (pkt.Quantity == 0 || pkt.Quantity > WarehouseMaxStackSize) {
+		err = fmt.Errorf("character '%d' is attempting to attach %d of item %d to mail", s.charID, pkt.Quantity, pkt.ItemID)
+	}
+
+	if err != nil {
+		s.logger.Warn("failed to send mail", zap.Error(err))
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	transaction, err := s.server.db.Begin()
+
+	if err != nil {
+		s.logger.Error("failed to start db transaction", zap.Error(err))
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	sent := make([]*Mail, 0, len(recipientIDs))
+
+	for _, recipientID := range recipientIDs {
+		count, err := GetMailCountForCharacter(s, recipientID)
+
+		if err != nil {
+			rollbackTransaction(s, transaction)
+			doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+			return
+		}
+
+		blocked, err := HasContact(s, recipientID, ContactListBlacklist, s.charID)
+
+		if err != nil {
+			rollbackTransaction(s, transaction)
+			doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+			return
+		}
+
+		if count >= MailInboxLimit || blocked {
+			// Guild mail still reaches the members who have room for it and haven't blocked the sender
+			if pkt.RecipientID == 0 {
+				continue
+			}
+
+			rollbackTransaction(s, transaction)
+			doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+			return
+		}
+
+		mail := &Mail{
+			SenderID:    s.charID,
+			RecipientID: recipientID,
+			Subject:     subject,
+			Body:        body,
+		}
+
+		if pkt.ItemID != 0 {
+			mail.AttachedItemID = &pkt.ItemID
+			mail.AttachedItemAmount = int16(pkt.Quantity)
+		}
+
+		err = mail.Send(s, transaction)
+
+		if err != nil {
+			rollbackTransaction(s, transaction)
+			doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+			return
+		}
+
+		sent = append(sent, mail)
+	}
+
+	err = transaction.Commit()
+
+	if err != nil {
+		s.logger.Error("failed to commit db transaction", zap.Error(err))
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	for _, mail := range sent {
+		recipient := s.server.FindSessionByCharID(mail.RecipientID)
+
+		if recipient != nil {
+			SendMailNotification(s, mail, recipient)
+		}
 	}
 
-	mail, err := GetMailByID(s, mailId)
+	doAckSimpleSucceed(s, pkt.AckHandle, make([]byte, 4))
+}
+
+// getGuildMailRecipients returns the members of the sender's guild other than the sender.
+func getGuildMailRecipients(s *Session) ([]uint32, error) {
+	guild, _, err := getMemberGuild(s)
 
 	if err != nil {
+		return nil, err
+	}
+
+	if guild == nil {
+		return nil, fmt.Errorf("character '%d' is attempting to send guild mail without a guild", s.charID)
+	}
+
+	members, err := GetGuildMembers(s, guild.ID, false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	recipientIDs := make([]uint32, 0, len(members))
+
+	for _, member := range members {
+		if member.CharID != s.charID {
+			recipientIDs = append(recipientIDs, member.CharID)
+		}
+	}
+
+	return recipientIDs, nil
+}
+
+func handleMsgMhfReadMail(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfReadMail)
+
+	mail, err := getSessionMail(s, pkt.AccIndex)
+
+	if err != nil {
+		s.logger.Warn("failed to read mail", zap.Error(err), zap.Uint8("accIndex", pkt.AccIndex))
 		doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
-		panic(err)
+		return
 	}
 
 	_ = mail.MarkRead(s)
@@ -39,23 +187,26 @@ func handleMsgMhfListMail(s *Session, p mhfpacket.MHFPacket) {
 
 	if err != nil {
 		doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
-		panic(err)
-	}
-
-	if s.mailList == nil {
-		s.mailList = make([]int, 256)
+		return
 	}
 
 	msg := byteframe.NewByteFrame()
 
 	msg.WriteUint32(uint32(len(mail)))
 
+	// Indexes from previous lists are dropped so they can't point at mail that has since been deleted or wrapped around
+	s.Lock()
+	s.mailList = make([]int, 256)
 	startIndex := s.mailAccIndex
 
 	for i, m := range mail {
-		accIndex := startIndex + uint8(i)
-		s.mailList[accIndex] = m.ID
+		s.mailList[startIndex+uint8(i)] = m.ID
 		s.mailAccIndex++
+	}
+	s.Unlock()
+
+	for i, m := range mail {
+		accIndex := startIndex + uint8(i)
 
 		itemAttached := m.AttachedItemID != nil
 		subjectBytes := []byte(stringsupport.MustConvertUTF8ToShiftJIS(m.Subject) + "\x00")
@@ -77,6 +228,10 @@ func handleMsgMhfListMail(s *Session, p mhfpacket.MHFPacket) {
 			flags |= 0x08
 		}
 
+		if m.IsSystemMail() {
+			flags |= 0x04
+		}
+
 		if m.IsGuildInvite {
 			// Guild Invite
 			flags |= 0x10
@@ -104,11 +259,12 @@ func handleMsgMhfListMail(s *Session, p mhfpacket.MHFPacket) {
 func handleMsgMhfOprtMail(s *Session, p mhfpacket.MHFPacket) {
 	pkt := p.(*mhfpacket.MsgMhfOprtMail)
 
-	mail, err := GetMailByID(s, s.mailList[pkt.AccIndex])
+	mail, err := getSessionMail(s, pkt.AccIndex)
 
 	if err != nil {
+		s.logger.Warn("failed to operate mail", zap.Error(err), zap.Uint8("accIndex", pkt.AccIndex))
 		doAckSimpleFail(s, pkt.AckHandle, nil)
-		panic(err)
+		return
 	}
 
 	switch mhfpacket.OperateMailOperation(pkt.Operation) {
@@ -117,9 +273,43 @@ func handleMsgMhfOprtMail(s *Session, p mhfpacket.MHFPacket) {
 
 		if err != nil {
 			doAckSimpleFail(s, pkt.AckHandle, nil)
-			panic(err)
+			return
+		}
+	case mhfpacket.OperateMailOperationAcquireItem:
+		err = mail.MarkAttachedItemReceived(s)
+
+		if err != nil {
+			doAckSimpleFail(s, pkt.AckHandle, nil)
+			return
 		}
 	}
 
 	doAckSimpleSucceed(s, pkt.AckHandle, nil)
 }
+
+// getSessionMail returns the mail the client refers to by the accumulated index it was listed with.
+func getSessionMail(s *Session, accIndex uint8) (*Mail, error) {
+	s.Lock()
+	mailID := 0
+
+	if int(accIndex) < len(s.mailList) {
+		mailID = s.mailList[accIndex]
+	}
+	s.Unlock()
+
+	if mailID == 0 {
+		return nil, fmt.Errorf("no mail listed at index %d", accIndex)
+	}
+
+	mail, err := GetMailByID(s, mailID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if mail.RecipientID != s.charID {
+		return nil, fmt.Errorf("mail '%d' does not belong to character '%d'", mail.ID, s.charID)
+	}
+
+	return mail, nil
+}
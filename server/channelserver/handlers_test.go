@@ -0,0 +1,24 @@
+package channelserver
+
+import (
+	"testing"
+)
+
+func TestGuildcardOwner(t *testing.T) {
+	s := &Session{charID: 1}
+
+	tests := []struct {
+		charID uint32
+		want   uint32
+	}{
+		{0, 1},
+		{1, 1},
+		{2, 2},
+	}
+
+	for _, tt := range tests {
+		if got := guildcardOwner(s, tt.charID); got != tt.want {
+			t.Errorf("guildcardOwner(%d) = %d, want %d", tt.charID, got, tt.want)
+		}
+	}
+}
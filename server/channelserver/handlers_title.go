@@ -0,0 +1,63 @@
+package channelserver
+
+import (
+	"github.com/Andoryuuta/Erupe/network/mhfpacket"
+	"github.com/Andoryuuta/byteframe"
+)
+
+func handleMsgMhfAcquireTitle(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfAcquireTitle)
+
+	err := AcquireTitles(s, pkt.TitleIDs)
+
+	if err != nil {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	doAckSimpleSucceed(s, pkt.AckHandle, make([]byte, 4))
+}
+
+func handleMsgMhfEnumerateTitle(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfEnumerateTitle)
+
+	// Zero is sent when listing our own titles.
+	charID := pkt.CharID
+
+	if charID == 0 {
+		charID = s.charID
+	}
+
+	titles, err := GetTitles(s, charID)
+
+	if err != nil {
+		doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	bf := byteframe.NewByteFrame()
+	bf.WriteUint16(uint16(len(titles)))
+	bf.WriteUint16(0) // Unk
+
+	for _, title := range titles {
+		bf.WriteUint16(title.ID)
+		bf.WriteUint16(0) // Unk
+		bf.WriteUint32(uint32(title.AcquiredAt.Unix()))
+		bf.WriteUint32(uint32(title.UpdatedAt.Unix()))
+	}
+
+	doAckBufSucceed(s, pkt.AckHandle, bf.Data())
+}
+
+func handleMsgMhfResetTitle(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfResetTitle)
+
+	err := ResetTitles(s)
+
+	if err != nil {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	doAckSimpleSucceed(s, pkt.AckHandle, make([]byte, 4))
+}
@@ -0,0 +1,156 @@
+package channelserver
+
+import (
+	"github.com/Andoryuuta/Erupe/common/stringsupport"
+	"github.com/Andoryuuta/Erupe/network/mhfpacket"
+	"github.com/Andoryuuta/byteframe"
+	"go.uber.org/zap"
+)
+
+const (
+	WarehouseOperationGetBoxNames = iota
+	WarehouseOperationGetPages
+	WarehouseOperationRename
+	WarehouseOperationGetUsageLimit
+	WarehouseOperationUse
+)
+
+func handleMsgMhfOperateWarehouse(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfOperateWarehouse)
+
+	warehouse, err := GetWarehouse(s, s.charID)
+
+	if err != nil {
+		doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	bf := byteframe.NewByteFrame()
+
+	switch pkt.Operation {
+	case WarehouseOperationGetBoxNames:
+		names := byteframe.NewByteFrame()
+		var count uint8
+
+		for _, boxType := range []uint8{WarehouseBoxItems, WarehouseBoxEquipment} {
+			for i := uint8(0); i < warehouse.Pages(boxType); i++ {
+				name := warehouse.BoxName(boxType, i)
+
+				if name == "" {
+					continue
+				}
+
+				name = stringsupport.MustConvertUTF8ToShiftJIS(name) + "\x00"
+
+				names.WriteUint8(boxType)
+				names.WriteUint8(i)
+				names.WriteUint8(uint8(len(name)))
+				names.WriteBytes([]byte(name))
+				count++
+			}
+		}
+
+		bf.WriteUint32(0)     // Usage limit renewal time
+		bf.WriteUint16(10000) // Usages remaining
+		bf.WriteUint8(count)
+		bf.WriteBytes(names.Data())
+	case WarehouseOperationGetPages:
+		bf.WriteUint8(warehouse.Pages(pkt.BoxType))
+	case WarehouseOperationRename:
+		name, err := stringsupport.ConvertShiftJISToUTF8(stripNullTerminator(pkt.Name))
+
+		if err != nil || warehouse.Rename(s, pkt.BoxType, pkt.BoxIndex, name) != nil {
+			doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
+			return
+		}
+	case WarehouseOperationGetUsageLimit:
+		bf.WriteUint32(0)     // Usage limit renewal time, anything above 1 disables the warehouse
+		bf.WriteUint16(10000) // Usages remaining
+	case WarehouseOperationUse:
+		bf.WriteUint32(0)     // Usage limit renewal time
+		bf.WriteUint16(10000) // Usages remaining
+		bf.WriteUint32(0)     // Unk
+	default:
+		s.logger.Warn("unknown warehouse operation", zap.Uint8("operation", pkt.Operation))
+		doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	doAckBufSucceed(s, pkt.AckHandle, bf.Data())
+}
+
+func handleMsgMhfEnumerateWarehouse(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfEnumerateWarehouse)
+
+	warehouse, err := GetWarehouse(s, s.charID)
+
+	if err != nil || !warehouse.IsUnlocked(pkt.BoxType, pkt.BoxIndex) {
+		doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	bf := byteframe.NewByteFrame()
+
+	if pkt.BoxType == WarehouseBoxEquipment {
+		equipment, err := warehouse.Equipment(s, pkt.BoxIndex)
+
+		if err != nil {
+			doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
+			return
+		}
+
+		bf.WriteUint16(uint16(len(equipment)))
+		bf.WriteUint16(0) // Unk
+
+		for _, e := range equipment {
+			e.Write(bf)
+		}
+	} else {
+		items, err := warehouse.Items(s, pkt.BoxIndex)
+
+		if err != nil {
+			doAckBufFail(s, pkt.AckHandle, make([]byte, 4))
+			return
+		}
+
+		bf.WriteUint16(uint16(len(items)))
+		bf.WriteUint16(0) // Unk
+
+		for _, item := range items {
+			item.Write(bf)
+		}
+	}
+
+	doAckBufSucceed(s, pkt.AckHandle, bf.Data())
+}
+
+func handleMsgMhfUpdateWarehouse(s *Session, p mhfpacket.MHFPacket) {
+	pkt := p.(*mhfpacket.MsgMhfUpdateWarehouse)
+
+	warehouse, err := GetWarehouse(s, s.charID)
+
+	if err != nil {
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	if pkt.BoxType == WarehouseBoxEquipment {
+		err = warehouse.UpdateEquipment(s, pkt.BoxIndex, pkt.UpdatedEquipment)
+	} else {
+		err = warehouse.UpdateItems(s, pkt.BoxIndex, pkt.UpdatedItems)
+	}
+
+	if err != nil {
+		s.logger.Warn(
+			"rejected warehouse update",
+			zap.Error(err),
+			zap.Uint32("charID", s.charID),
+			zap.Uint8("boxType", pkt.BoxType),
+			zap.Uint8("boxIndex", pkt.BoxIndex),
+		)
+		doAckSimpleFail(s, pkt.AckHandle, make([]byte, 4))
+		return
+	}
+
+	doAckSimpleSucceed(s, pkt.AckHandle, make([]byte, 4))
+}
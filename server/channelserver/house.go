@@ -0,0 +1,246 @@
+package channelserver
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// House access settings chosen by the owner.
+const (
+	HouseStateEveryone uint8 = iota + 1
+	HouseStateFriends
+	HouseStateGuild
+)
+
+const (
+	houseInteriorSize   = 20
+	houseSearchMaxCount = 100
+)
+
+// Houses are stages named after their owner, followed by the owner's character ID in hex.
+const houseStagePrefix = "sl1Ns257p0a0u"
+
+type House struct {
+	CharID    uint32 `db:"id"`
+	Name      string `db:"name"`
+	HR        uint16 `db:"hr"`
+	GR        uint16 `db:"gr"`
+	State     uint8  `db:"house_state"`
+	Tier      []byte `db:"house_tier"`
+	Data      []byte `db:"house_data"`
+	Furniture []byte `db:"house_furniture"`
+
+	// bcrypt hash of the house password, empty when the house has none.
+	PasswordHash string `db:"house_password_hash"`
+}
+
+const houseSelectSQL = `
+	SELECT id, name, COALESCE(exp, 0) AS hr, COALESCE(gr_override_level, 0) AS gr,
+		house_state, house_password_hash, house_tier, house_data, house_furniture
+	FROM characters
+`
+
+func GetHouse(s *Session, charID uint32) (*House, error) {
+	house := &House{}
+
+	err := s.server.db.QueryRowx(houseSelectSQL+`WHERE id = $1`, charID).StructScan(house)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		s.logger.Error("failed to retrieve house", zap.Error(err), zap.Uint32("charID", charID))
+		return nil, err
+	}
+
+	return house, nil
+}
+
+// SearchHouses finds houses by (partial) owner name.
+func SearchHouses(s *Session, name string) ([]*House, error) {
+	return queryHouses(s, houseSelectSQL+`WHERE name ILIKE $1 ORDER BY name LIMIT $2`, "%"+name+"%", houseSearchMaxCount)
+}
+
+func GetGuildHouses(s *Session, guildID uint32) ([]*House, error) {
+	return queryHouses(s, houseSelectSQL+`
+		WHERE id IN (SELECT character_id FROM guild_characters WHERE guild_id = $1)
+		ORDER BY name
+	`, guildID)
+}
+
+// GetFriendHouses returns the houses of the characters on the given character's friends list.
+func GetFriendHouses(s *Session, charID uint32) ([]*House, error) {
+	return queryHouses(s, houseSelectSQL+`
+		WHERE id IN (SELECT friend_id FROM character_friends WHERE character_id = $1)
+		ORDER BY name
+	`, charID)
+}
+
+func queryHouses(s *Session, query string, args ...interface{}) ([]*House, error) {
+	rows, err := s.server.db.Queryx(query, args...)
+
+	if err != nil {
+		s.logger.Error("failed to retrieve houses", zap.Error(err))
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	houses := make([]*House, 0)
+
+	for rows.Next() {
+		house := &House{}
+
+		err = rows.StructScan(house)
+
+		if err != nil {
+			return nil, err
+		}
+
+		houses = append(houses, house)
+	}
+
+	return houses, nil
+}
+
+func (h *House) HasPassword() bool {
+	return h.PasswordHash != ""
+}
+
+// SetPassword hashes the password for storage, an empty password removes it.
+func (h *House) SetPassword(password string) error {
+	if password == "" {
+		h.PasswordHash = ""
+		return nil
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+
+	if err != nil {
+		return err
+	}
+
+	h.PasswordHash = string(hash)
+
+	return nil
+}
+
+func (h *House) CheckPassword(password string) bool {
+	if !h.HasPassword() {
+		return true
+	}
+
+	return bcrypt.CompareHashAndPassword([]byte(h.PasswordHash), []byte(password)) == nil
+}
+
+// CanVisit checks the owner's access setting for the visiting session.
+func (h *House) CanVisit(s *Session, password string) bool {
+	if h.CharID == s.charID {
+		return true
+	}
+
+	if !h.CheckPassword(password) {
+		return false
+	}
+
+	switch h.State {
+	case HouseStateEveryone:
+		return true
+	case HouseStateFriends:
+		isFriend, err := HasContact(s, h.CharID, ContactListFriends, s.charID)
+
+		return err == nil && isFriend
+	case HouseStateGuild:
+		ownerGuild, err := GetCharacterGuildData(s, h.CharID)
+
+		if err != nil || ownerGuild == nil {
+			return false
+		}
+
+		visitorGuild, err := GetCharacterGuildData(s, s.charID)
+
+		if err != nil || visitorGuild == nil {
+			return false
+		}
+
+		return ownerGuild.GuildID == visitorGuild.GuildID && !ownerGuild.IsApplicant && !visitorGuild.IsApplicant
+	default:
+		return false
+	}
+}
+
+func (h *House) SaveAccess(s *Session) error {
+	_, err := s.server.db.Exec(`
+		UPDATE characters SET house_state = $1, house_password_hash = $2 WHERE id = $3
+	`, h.State, h.PasswordHash, h.CharID)
+
+	if err != nil {
+		s.logger.Error("failed to update house access", zap.Error(err), zap.Uint32("charID", h.CharID))
+		return err
+	}
+
+	return nil
+}
+
+func (h *House) SaveFurniture(s *Session) error {
+	_, err := s.server.db.Exec(`
+		UPDATE characters SET house_furniture = $1 WHERE id = $2
+	`, h.Furniture, h.CharID)
+
+	if err != nil {
+		s.logger.Error("failed to update house furniture", zap.Error(err), zap.Uint32("charID", h.CharID))
+		return err
+	}
+
+	return nil
+}
+
+// houseStageOwner returns the owner of a house stage, reporting false for any other stage.
+func houseStageOwner(stageID string) (uint32, bool) {
+	if !strings.HasPrefix(stageID, houseStagePrefix) {
+		return 0, false
+	}
+
+	charID, err := strconv.ParseUint(strings.TrimPrefix(stageID, houseStagePrefix), 16, 32)
+
+	if err != nil {
+		return 0, false
+	}
+
+	return uint32(charID), true
+}
+
+// canEnterStage checks house access for house stages, using the password last accepted when loading the house.
+// Every other stage can be entered freely.
+func canEnterStage(s *Session, stageID string) bool {
+	ownerID, isHouse := houseStageOwner(stripNullTerminator(stageID))
+
+	if !isHouse {
+		return true
+	}
+
+	house, err := GetHouse(s, ownerID)
+
+	if err != nil || house == nil {
+		return false
+	}
+
+	s.Lock()
+	password := s.housePasswords[ownerID]
+	s.Unlock()
+
+	return house.CanVisit(s, password)
+}
+
+// fixedSize pads or truncates stored house data to the size the client expects.
+func fixedSize(data []byte, size int) []byte {
+	fixed := make([]byte, size)
+	copy(fixed, data)
+	return fixed
+}
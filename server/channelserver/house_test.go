@@ -0,0 +1,55 @@
+package channelserver
+
+import (
+	"testing"
+)
+
+func TestHouseStageOwner(t *testing.T) {
+	tests := []struct {
+		stageID string
+		wantID  uint32
+		wantOK  bool
+	}{
+		{"sl1Ns257p0a0uE31111", 0xE31111, true},
+		{"sl1Ns257p0a0u1", 1, true},
+		{"sl1Ns200p0a0u0", 0, false},
+		{"sl1Ns257p0a0u", 0, false},
+		{"sl1Ns257p0a0uZZ", 0, false},
+	}
+
+	for _, tt := range tests {
+		gotID, gotOK := houseStageOwner(tt.stageID)
+
+		if gotID != tt.wantID || gotOK != tt.wantOK {
+			t.Errorf("houseStageOwner(%q) = %d, %v, want %d, %v", tt.stageID, gotID, gotOK, tt.wantID, tt.wantOK)
+		}
+	}
+}
+
+func TestHousePassword(t *testing.T) {
+	house := &House{}
+
+	if !house.CheckPassword("anything") {
+		t.Error("house without a password rejected a password")
+	}
+
+	if err := house.SetPassword("1234"); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+
+	if !house.HasPassword() || house.PasswordHash == "1234" {
+		t.Fatalf("got hash %q, want a bcrypt hash", house.PasswordHash)
+	}
+
+	if !house.CheckPassword("1234") {
+		t.Error("correct password rejected")
+	}
+
+	if house.CheckPassword("4321") || house.CheckPassword("") {
+		t.Error("wrong password accepted")
+	}
+
+	if err := house.SetPassword(""); err != nil || house.HasPassword() {
+		t.Errorf("clearing the password left hash %q, error %v", house.PasswordHash, err)
+	}
+}
@@ -2,6 +2,7 @@ package channelserver
 
 import (
 	"database/sql"
+	"errors"
 	"github.com/Andoryuuta/Erupe/network/binpacket"
 	"github.com/Andoryuuta/Erupe/network/mhfpacket"
 	"github.com/Andoryuuta/byteframe"
@@ -9,6 +10,21 @@ import (
 	"time"
 )
 
+// Most undeleted mails a character can hold, also the most the client can list.
+const MailInboxLimit = 32
+
+const (
+	// How often the mailbox cleanup runs.
+	mailExpiryInterval = time.Hour
+	// Days read mail is kept before it expires, unless it still holds an unreceived item.
+	MailReadExpiryDays = 30
+)
+
+var (
+	ErrMailInboxFull           = errors.New("recipient's inbox is full")
+	ErrMailItemAlreadyReceived = errors.New("mail has no attached item left to receive")
+)
+
 type Mail struct {
 	ID                   int       `db:"id"`
 	SenderID             uint32    `db:"sender_id"`
@@ -28,7 +44,7 @@ type Mail struct {
 func (m *Mail) Send(s *Session, transaction *sql.Tx) error {
 	query := `
 		INSERT INTO mail (sender_id, recipient_id, subject, body, attached_item, attached_item_amount, is_guild_invite)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		VALUES (NULLIF($1, 0), $2, $3, $4, $5, $6, $7)
 	`
 
 	var err error
@@ -91,26 +107,74 @@ func (m *Mail) MarkDeleted(s *Session) error {
 	return nil
 }
 
+// MarkAttachedItemReceived hands out the attached item, failing if it was already received.
+func (m *Mail) MarkAttachedItemReceived(s *Session) error {
+	result, err := s.server.db.Exec(`
+		UPDATE mail SET attached_item_received = true
+		WHERE id = $1 AND attached_item IS NOT NULL AND attached_item_received = false
+	`, m.ID)
+
+	if err != nil {
+		s.logger.Error(
+			"failed to mark mail item as received",
+			zap.Error(err),
+			zap.Int("mailID", m.ID),
+		)
+		return err
+	}
+
+	received, err := result.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if received == 0 {
+		return ErrMailItemAlreadyReceived
+	}
+
+	m.AttachedItemReceived = true
+
+	return nil
+}
+
+// GetMailCountForCharacter returns how many undeleted mails the character holds.
+func GetMailCountForCharacter(s *Session, charID uint32) (int, error) {
+	var count int
+
+	err := s.server.db.QueryRow(`
+		SELECT COUNT(*) FROM mail WHERE recipient_id = $1 AND deleted = false
+	`, charID).Scan(&count)
+
+	if err != nil {
+		s.logger.Error("failed to count mail for character", zap.Error(err), zap.Uint32("charID", charID))
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func GetMailListForCharacter(s *Session, charID uint32) ([]Mail, error) {
 	rows, err := s.server.db.Queryx(`
 		SELECT 
 			m.id,
-			m.sender_id,
+			coalesce(m.sender_id, 0) AS sender_id,
 			m.recipient_id,
 			m.subject,
 			m.read,
 			m.attached_item,
 			m.attached_item_amount,
+			m.attached_item_received,
 			m.created_at,
 			m.is_guild_invite,
 			m.deleted,
-			c.name as sender_name
+			coalesce(c.name, '') as sender_name
 		FROM mail m 
-			JOIN characters c ON c.id = m.sender_id 
+			LEFT JOIN characters c ON c.id = m.sender_id 
 		WHERE recipient_id = $1 AND deleted = false
 		ORDER BY m.created_at DESC, id DESC
-		LIMIT 32
-	`, charID)
+		LIMIT $2
+	`, charID, MailInboxLimit)
 
 	if err != nil {
 		s.logger.Error("failed to get mail for character", zap.Error(err), zap.Uint32("charID", charID))
@@ -140,19 +204,20 @@ func GetMailByID(s *Session, ID int) (*Mail, error) {
 	row := s.server.db.QueryRowx(`
 		SELECT 
 			m.id,
-			m.sender_id,
+			coalesce(m.sender_id, 0) AS sender_id,
 			m.recipient_id,
 			m.subject,
 			m.read,
 			m.body,
 			m.attached_item,
 			m.attached_item_amount,
+			m.attached_item_received,
 			m.created_at,
 			m.is_guild_invite,
 			m.deleted,
-			c.name as sender_name
+			coalesce(c.name, '') as sender_name
 		FROM mail m 
-			JOIN characters c ON c.id = m.sender_id 
+			LEFT JOIN characters c ON c.id = m.sender_id 
 		WHERE m.id = $1
 		LIMIT 1
 	`, ID)
@@ -173,11 +238,42 @@ func GetMailByID(s *Session, ID int) (*Mail, error) {
 	return mail, nil
 }
 
-func SendMailNotification(s *Session, m *Mail, recipient *Session) {
-	senderName, err := getCharacterName(s, m.SenderID)
+// IsSystemMail reports whether the mail was sent by the server rather than a character.
+func (m *Mail) IsSystemMail() bool {
+	return m.SenderID == 0
+}
+
+// SendSystemMail delivers a mail from the server itself, notifying the recipient if they're online.
+func SendSystemMail(s *Session, m *Mail) error {
+	m.SenderID = 0
+
+	err := m.Send(s, nil)
 
 	if err != nil {
-		panic(err)
+		return err
+	}
+
+	recipient := s.server.FindSessionByCharID(m.RecipientID)
+
+	if recipient != nil {
+		SendMailNotification(s, m, recipient)
+	}
+
+	return nil
+}
+
+func SendMailNotification(s *Session, m *Mail, recipient *Session) {
+	senderName := ""
+
+	if !m.IsSystemMail() {
+		var err error
+
+		senderName, err = getCharacterName(s, m.SenderID)
+
+		if err != nil {
+			s.logger.Error("failed to retrieve mail sender name", zap.Error(err), zap.Uint32("senderID", m.SenderID))
+			return
+		}
 	}
 
 	bf := byteframe.NewByteFrame()
@@ -213,3 +309,53 @@ func getCharacterName(s *Session, charID uint32) (string, error) {
 
 	return charName, nil
 }
+
+// expireMail periodically deletes old read mail and trims inboxes down to MailInboxLimit.
+func (s *Server) expireMail() {
+	ticker := time.NewTicker(mailExpiryInterval)
+	defer ticker.Stop()
+
+	for {
+		s.Lock()
+		shutdown := s.isShuttingDown
+		s.Unlock()
+
+		if shutdown {
+			return
+		}
+
+		s.pruneMail()
+
+		<-ticker.C
+	}
+}
+
+func (s *Server) pruneMail() {
+	_, err := s.db.Exec(`
+		UPDATE mail SET deleted = true
+		WHERE deleted = false AND read = true AND created_at < now() - make_interval(days => $1)
+			AND (attached_item IS NULL OR attached_item_received = true)
+	`, MailReadExpiryDays)
+
+	if err != nil {
+		s.logger.Error("failed to expire read mail", zap.Error(err))
+	}
+
+	// Unread mail is kept over read mail, then newer over older. Mail with an unreceived item is never removed,
+	// so guild and system mail can still push an inbox past the limit.
+	_, err = s.db.Exec(`
+		UPDATE mail SET deleted = true
+		WHERE id IN (
+			SELECT id FROM (
+				SELECT id, row_number() OVER (PARTITION BY recipient_id ORDER BY read, created_at DESC, id DESC) AS position
+				FROM mail
+				WHERE deleted = false AND (attached_item IS NULL OR attached_item_received = true)
+			) inbox
+			WHERE inbox.position > $1
+		)
+	`, MailInboxLimit)
+
+	if err != nil {
+		s.logger.Error("failed to cap mail inboxes", zap.Error(err))
+	}
+}
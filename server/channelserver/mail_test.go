@@ -0,0 +1,33 @@
+package channelserver
+
+import (
+	"testing"
+)
+
+func TestGetSessionMailOutOfRange(t *testing.T) {
+	s := &Session{mailList: []int{0, 12}}
+
+	for _, accIndex := range []uint8{0, 2, 255} {
+		if _, err := getSessionMail(s, accIndex); err == nil {
+			t.Errorf("getSessionMail(%d) got nil error, want an error", accIndex)
+		}
+	}
+}
+
+func TestMailIsSystemMail(t *testing.T) {
+	tests := []struct {
+		senderID uint32
+		want     bool
+	}{
+		{0, true},
+		{1, false},
+	}
+
+	for _, tt := range tests {
+		mail := &Mail{SenderID: tt.senderID}
+
+		if got := mail.IsSystemMail(); got != tt.want {
+			t.Errorf("mail from %d: IsSystemMail() = %v, want %v", tt.senderID, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package channelserver
+
+import (
+	"fmt"
+
+	"github.com/Andoryuuta/byteframe"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// Size of the quest result record sent with MSG_SYS_RECORD_LOG.
+const QuestResultRecordSize = 0x4AC
+
+// Offsets of the known fields within the quest result record.
+const (
+	questResultQuestIDOffset   = 0x00
+	questResultClearTimeOffset = 0x04
+	questResultWeaponOffset    = 0x08
+	questResultKillsOffset     = 0x20
+
+	// Number of monster IDs covered by the kill counts, one byte per monster.
+	QuestResultKillsCount = 176
+)
+
+type QuestResult struct {
+	CharID    uint32
+	QuestID   uint32
+	ClearTime uint32 // Frames, the client runs at 30 per second
+	Weapon    uint16
+	Party     []uint32
+	Kills     []byte // Kill count indexed by monster ID
+	Record    []byte
+}
+
+// ParseQuestResult decodes the quest result record a character sends when returning from a quest.
+func ParseQuestResult(charID uint32, record []byte) (*QuestResult, error) {
+	if len(record) < QuestResultRecordSize {
+		return nil, fmt.Errorf("quest result record too short, got %d bytes", len(record))
+	}
+
+	bf := byteframe.NewByteFrameFromBytes(record)
+
+	result := &QuestResult{
+		CharID: charID,
+		Record: record,
+	}
+
+	_, _ = bf.Seek(questResultQuestIDOffset, 0)
+	result.QuestID = bf.ReadUint32()
+
+	_, _ = bf.Seek(questResultClearTimeOffset, 0)
+	result.ClearTime = bf.ReadUint32()
+
+	_, _ = bf.Seek(questResultWeaponOffset, 0)
+	result.Weapon = bf.ReadUint16()
+
+	_, _ = bf.Seek(questResultKillsOffset, 0)
+	result.Kills = bf.ReadBytes(QuestResultKillsCount)
+
+	return result, nil
+}
+
+func (qr *QuestResult) Save(s *Session) error {
+	party := make(pq.Int64Array, len(qr.Party))
+
+	for i, charID := range qr.Party {
+		party[i] = int64(charID)
+	}
+
+	_, err := s.server.db.Exec(`
+		INSERT INTO quest_logs (character_id, quest_id, clear_time, weapon, party, kills, record)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, qr.CharID, qr.QuestID, qr.ClearTime, qr.Weapon, party, qr.Kills, qr.Record)
+
+	if err != nil {
+		s.logger.Error(
+			"failed to save quest result",
+			zap.Error(err),
+			zap.Uint32("charID", qr.CharID),
+			zap.Uint32("questID", qr.QuestID),
+		)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package channelserver
+
+import (
+	"testing"
+)
+
+func TestParseQuestResult(t *testing.T) {
+	record := make([]byte, QuestResultRecordSize)
+	copy(record[questResultQuestIDOffset:], []byte{0x00, 0x00, 0xEA, 0x61})
+	copy(record[questResultClearTimeOffset:], []byte{0x00, 0x00, 0x1C, 0x20})
+	copy(record[questResultWeaponOffset:], []byte{0x00, 0x07})
+	record[questResultKillsOffset+11] = 2
+
+	result, err := ParseQuestResult(1, record)
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+
+	if result.QuestID != 0xEA61 {
+		t.Errorf("got quest ID 0x%X, want 0xEA61", result.QuestID)
+	}
+
+	if result.ClearTime != 0x1C20 {
+		t.Errorf("got clear time %d, want %d", result.ClearTime, 0x1C20)
+	}
+
+	if result.Weapon != 7 {
+		t.Errorf("got weapon %d, want 7", result.Weapon)
+	}
+
+	if len(result.Kills) != QuestResultKillsCount || result.Kills[11] != 2 {
+		t.Errorf("got kills %v, want 2 kills of monster 11", result.Kills)
+	}
+}
+
+func TestParseQuestResultShortRecord(t *testing.T) {
+	_, err := ParseQuestResult(1, make([]byte, 0x20))
+	if err == nil {
+		t.Error("got nil error for a short record, want an error")
+	}
+}
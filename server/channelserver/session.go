@@ -38,6 +38,12 @@ type Session struct {
 	mailAccIndex uint8
 	// Contains the mail list that maps accumulated indexes to mail IDs
 	mailList []int
+
+	// Passwords accepted when loading other characters' houses, by owner, checked again when entering their stage.
+	housePasswords map[uint32]string
+
+	// Guild item box stack quantities as last sent to the client, by stack ID, so its updates can be applied as changes.
+	guildItemsSeen map[uint32]uint16
 }
 
 // NewSession creates a new Session type.
@@ -49,6 +55,8 @@ func NewSession(server *Server, conn net.Conn) *Session {
 		cryptConn:      network.NewCryptConn(conn),
 		sendPackets:    make(chan []byte, 20),
 		stageMoveStack: stringstack.New(),
+		housePasswords: make(map[uint32]string),
+		guildItemsSeen: make(map[uint32]uint16),
 	}
 	return s
 }
@@ -141,6 +149,7 @@ func (s *Session) recvLoop() {
 
 		if err != nil {
 			s.logger.Warn("Error on ReadPacket, exiting recv loop", zap.Error(err))
+			logoutPlayer(s)
 			return
 		}
 
@@ -49,6 +49,9 @@ type Stage struct {
 	maxPlayers  uint16
 	hasDeparted bool
 	password    string
+
+	// Guilds that have been credited with the kills of the quest run in this stage.
+	guildKillsRecorded map[uint32]bool
 }
 
 // NewStage creates a new stage with intialized values.
@@ -61,13 +64,34 @@ func NewStage(ID string) *Stage {
 		rawBinaryData:       make(map[stageBinaryKey][]byte),
 		maxPlayers:          4,
 		gameObjectCount:     1,
+		guildKillsRecorded:  make(map[uint32]bool),
 	}
 
 	return s
 }
 
+// claimGuildKills reports whether the guild is yet to be credited with the kills of the stage's quest run,
+// marking it as credited.
+func (s *Stage) claimGuildKills(guildID uint32) bool {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.guildKillsRecorded[guildID] {
+		return false
+	}
+
+	s.guildKillsRecorded[guildID] = true
+
+	return true
+}
+
 // BroadcastMHF queues a MHFPacket to be sent to all sessions in the stage.
 func (s *Stage) BroadcastMHF(pkt mhfpacket.MHFPacket, ignoredSession *Session) {
+	s.BroadcastMHFExcept(pkt, ignoredSession, nil)
+}
+
+// BroadcastMHFExcept works like BroadcastMHF, also skipping the sessions of the excluded characters.
+func (s *Stage) BroadcastMHFExcept(pkt mhfpacket.MHFPacket, ignoredSession *Session, excludedCharIDs map[uint32]bool) {
 	// Make the header
 	bf := byteframe.NewByteFrame()
 	bf.WriteUint16(uint16(pkt.Opcode()))
@@ -77,7 +101,7 @@ func (s *Stage) BroadcastMHF(pkt mhfpacket.MHFPacket, ignoredSession *Session) {
 
 	// Broadcast the data.
 	for session := range s.clients {
-		if session == ignoredSession {
+		if session == ignoredSession || excludedCharIDs[session.charID] {
 			continue
 		}
 		// Enqueue in a non-blocking way that drops the packet if the connections send buffer channel is full.
@@ -0,0 +1,30 @@
+package channelserver
+
+import (
+	"testing"
+)
+
+func TestStageClaimGuildKills(t *testing.T) {
+	stage := NewStage("sl1Ns200p0a0u0")
+
+	tests := []struct {
+		guildID uint32
+		want    bool
+	}{
+		{1, true},
+		{1, false},
+		{2, true},
+		{1, false},
+		{2, false},
+	}
+
+	for i, tt := range tests {
+		if got := stage.claimGuildKills(tt.guildID); got != tt.want {
+			t.Errorf("claim %d: claimGuildKills(%d) = %v, want %v", i, tt.guildID, got, tt.want)
+		}
+	}
+
+	if !NewStage("sl1Ns200p0a0u0").claimGuildKills(1) {
+		t.Error("claimGuildKills on a new stage = false, want true")
+	}
+}
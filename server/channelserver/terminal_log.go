@@ -0,0 +1,139 @@
+package channelserver
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/Andoryuuta/Erupe/network/mhfpacket"
+	"go.uber.org/zap"
+)
+
+type TerminalLog struct {
+	ID        int       `db:"id"`
+	LogID     uint32    `db:"log_id"`
+	CharID    uint32    `db:"character_id"`
+	U0        uint32    `db:"u0"`
+	U1        uint32    `db:"u1"`
+	U2        uint32    `db:"u2"`
+	U3        uint32    `db:"u3"`
+	U4        uint32    `db:"u4"`
+	U5        uint32    `db:"u5"`
+	U6        uint32    `db:"u6"`
+	U7        uint32    `db:"u7"`
+	U8        uint32    `db:"u8"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// CreateTerminalLogSession issues a new log ID for the session's character.
+func CreateTerminalLogSession(s *Session) (uint32, error) {
+	var logID uint32
+
+	err := s.server.db.QueryRow(
+		"INSERT INTO terminal_log_sessions (character_id) VALUES ($1) RETURNING id",
+		s.charID,
+	).Scan(&logID)
+
+	if err != nil {
+		s.logger.Error("failed to create terminal log session", zap.Error(err), zap.Uint32("charID", s.charID))
+		return 0, err
+	}
+
+	return logID, nil
+}
+
+// IsTerminalLogSessionOwner checks whether the log ID was issued to the session's character.
+func IsTerminalLogSessionOwner(s *Session, logID uint32) (bool, error) {
+	var num int
+
+	err := s.server.db.QueryRow(
+		"SELECT 1 FROM terminal_log_sessions WHERE id = $1 AND character_id = $2",
+		logID, s.charID,
+	).Scan(&num)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+
+	if err != nil {
+		s.logger.Error(
+			"failed to retrieve terminal log session",
+			zap.Error(err),
+			zap.Uint32("logID", logID),
+			zap.Uint32("charID", s.charID),
+		)
+		return false, err
+	}
+
+	return true, nil
+}
+
+func SaveTerminalLogEntries(s *Session, logID uint32, entries []*mhfpacket.TerminalLogEntry) error {
+	transaction, err := s.server.db.Begin()
+
+	if err != nil {
+		s.logger.Error("failed to start db transaction", zap.Error(err))
+		return err
+	}
+
+	for _, e := range entries {
+		_, err = transaction.Exec(`
+			INSERT INTO terminal_logs (log_id, character_id, u0, u1, u2, u3, u4, u5, u6, u7, u8)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		`, logID, s.charID, e.U0, e.U1, e.U2, e.U3, e.U4, e.U5, e.U6, e.U7, e.U8)
+
+		if err != nil {
+			s.logger.Error(
+				"failed to save terminal log entry",
+				zap.Error(err),
+				zap.Uint32("logID", logID),
+				zap.Uint32("charID", s.charID),
+			)
+			rollbackTransaction(s, transaction)
+			return err
+		}
+	}
+
+	err = transaction.Commit()
+
+	if err != nil {
+		s.logger.Error("failed to commit db transaction", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// GetTerminalLogsForCharacter returns the most recent terminal log entries sent by a character.
+func GetTerminalLogsForCharacter(s *Session, charID uint32, limit int) ([]*TerminalLog, error) {
+	rows, err := s.server.db.Queryx(`
+		SELECT id, log_id, character_id, u0, u1, u2, u3, u4, u5, u6, u7, u8, created_at
+		FROM terminal_logs
+		WHERE character_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2
+	`, charID, limit)
+
+	if err != nil {
+		s.logger.Error("failed to get terminal logs for character", zap.Error(err), zap.Uint32("charID", charID))
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	logs := make([]*TerminalLog, 0)
+
+	for rows.Next() {
+		log := &TerminalLog{}
+
+		err = rows.StructScan(log)
+
+		if err != nil {
+			return nil, err
+		}
+
+		logs = append(logs, log)
+	}
+
+	return logs, nil
+}
@@ -0,0 +1,71 @@
+package channelserver
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type Title struct {
+	ID         uint16    `db:"title_id"`
+	AcquiredAt time.Time `db:"acquired_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+// AcquireTitles gives titles to the session's character, refreshing the update time of titles it already has.
+func AcquireTitles(s *Session, titleIDs []uint16) error {
+	transaction, err := s.server.db.Begin()
+
+	if err != nil {
+		s.logger.Error("failed to start db transaction", zap.Error(err))
+		return err
+	}
+
+	for _, titleID := range titleIDs {
+		_, err = transaction.Exec(`
+			INSERT INTO titles (character_id, title_id) VALUES ($1, $2)
+			ON CONFLICT (character_id, title_id) DO UPDATE SET updated_at = now()
+		`, s.charID, titleID)
+
+		if err != nil {
+			s.logger.Error("failed to acquire title", zap.Error(err), zap.Uint32("charID", s.charID), zap.Uint16("titleID", titleID))
+			rollbackTransaction(s, transaction)
+			return err
+		}
+	}
+
+	err = transaction.Commit()
+
+	if err != nil {
+		s.logger.Error("failed to commit db transaction", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func GetTitles(s *Session, charID uint32) ([]Title, error) {
+	titles := make([]Title, 0)
+
+	err := s.server.db.Select(&titles, `
+		SELECT title_id, acquired_at, updated_at FROM titles WHERE character_id = $1 ORDER BY title_id
+	`, charID)
+
+	if err != nil {
+		s.logger.Error("failed to retrieve titles", zap.Error(err), zap.Uint32("charID", charID))
+		return nil, err
+	}
+
+	return titles, nil
+}
+
+func ResetTitles(s *Session) error {
+	_, err := s.server.db.Exec("DELETE FROM titles WHERE character_id = $1", s.charID)
+
+	if err != nil {
+		s.logger.Error("failed to reset titles", zap.Error(err), zap.Uint32("charID", s.charID))
+		return err
+	}
+
+	return nil
+}
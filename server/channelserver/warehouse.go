@@ -0,0 +1,344 @@
+package channelserver
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Andoryuuta/Erupe/network/mhfpacket"
+	"github.com/Andoryuuta/byteframe"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+const (
+	WarehouseBoxItems uint8 = iota
+	WarehouseBoxEquipment
+)
+
+const (
+	WarehouseMaxPages     = 10
+	WarehouseBoxCapacity  = 200
+	WarehouseMaxStackSize = 9999
+)
+
+var ErrWarehouseBoxLocked = errors.New("warehouse box is locked")
+
+type Warehouse struct {
+	CharID     uint32         `db:"character_id"`
+	ItemPages  uint8          `db:"item_pages"`
+	EquipPages uint8          `db:"equip_pages"`
+	ItemNames  pq.StringArray `db:"item_names"`
+	EquipNames pq.StringArray `db:"equip_names"`
+}
+
+// GetWarehouse returns the character's warehouse, creating it with the default unlocked pages if needed.
+func GetWarehouse(s *Session, charID uint32) (*Warehouse, error) {
+	_, err := s.server.db.Exec(`
+		INSERT INTO warehouse (character_id) VALUES ($1) ON CONFLICT DO NOTHING
+	`, charID)
+
+	if err != nil {
+		s.logger.Error("failed to create warehouse", zap.Error(err), zap.Uint32("charID", charID))
+		return nil, err
+	}
+
+	warehouse := &Warehouse{}
+
+	err = s.server.db.QueryRowx(`
+		SELECT character_id, item_pages, equip_pages, item_names, equip_names FROM warehouse WHERE character_id = $1
+	`, charID).StructScan(warehouse)
+
+	if err != nil {
+		s.logger.Error("failed to retrieve warehouse", zap.Error(err), zap.Uint32("charID", charID))
+		return nil, err
+	}
+
+	return warehouse, nil
+}
+
+func (w *Warehouse) Pages(boxType uint8) uint8 {
+	if boxType == WarehouseBoxEquipment {
+		return w.EquipPages
+	}
+
+	return w.ItemPages
+}
+
+func (w *Warehouse) IsUnlocked(boxType uint8, boxIndex uint8) bool {
+	return boxType <= WarehouseBoxEquipment && boxIndex < w.Pages(boxType)
+}
+
+// unlockWarehousePages adds pages to one of the character's warehouse boxes, up to WarehouseMaxPages.
+func unlockWarehousePages(s *Session, transaction *sql.Tx, charID uint32, boxType uint8, pages uint16) error {
+	column := "item_pages"
+
+	if boxType == WarehouseBoxEquipment {
+		column = "equip_pages"
+	}
+
+	// New warehouses start with a single page unlocked, like the column default.
+	_, err := transaction.Exec(fmt.Sprintf(`
+		INSERT INTO warehouse (character_id, %[1]s) VALUES ($1, LEAST(1 + $2, $3))
+		ON CONFLICT (character_id) DO UPDATE SET %[1]s = LEAST(warehouse.%[1]s + $2, $3)
+	`, column), charID, pages, WarehouseMaxPages)
+
+	if err != nil {
+		s.logger.Error(
+			"failed to unlock warehouse pages",
+			zap.Error(err),
+			zap.Uint32("charID", charID),
+			zap.Uint8("boxType", boxType),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (w *Warehouse) names(boxType uint8) []string {
+	if boxType == WarehouseBoxEquipment {
+		return w.EquipNames
+	}
+
+	return w.ItemNames
+}
+
+func (w *Warehouse) BoxName(boxType uint8, boxIndex uint8) string {
+	names := w.names(boxType)
+
+	if int(boxIndex) >= len(names) {
+		return ""
+	}
+
+	return names[boxIndex]
+}
+
+func (w *Warehouse) Rename(s *Session, boxType uint8, boxIndex uint8, name string) error {
+	if !w.IsUnlocked(boxType, boxIndex) {
+		return ErrWarehouseBoxLocked
+	}
+
+	names := make(pq.StringArray, WarehouseMaxPages)
+	copy(names, w.names(boxType))
+	names[boxIndex] = name
+
+	column := "item_names"
+
+	if boxType == WarehouseBoxEquipment {
+		column = "equip_names"
+	}
+
+	_, err := s.server.db.Exec(fmt.Sprintf("UPDATE warehouse SET %s = $1 WHERE character_id = $2", column), names, w.CharID)
+
+	if err != nil {
+		s.logger.Error("failed to rename warehouse box", zap.Error(err), zap.Uint32("charID", w.CharID))
+		return err
+	}
+
+	if boxType == WarehouseBoxEquipment {
+		w.EquipNames = names
+	} else {
+		w.ItemNames = names
+	}
+
+	return nil
+}
+
+func (w *Warehouse) loadBox(s *Session, boxType uint8, boxIndex uint8) ([]byte, error) {
+	var data []byte
+
+	err := s.server.db.QueryRow(`
+		SELECT data FROM warehouse_boxes WHERE character_id = $1 AND box_type = $2 AND box_index = $3
+	`, w.CharID, boxType, boxIndex).Scan(&data)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		s.logger.Error(
+			"failed to load warehouse box",
+			zap.Error(err),
+			zap.Uint32("charID", w.CharID),
+			zap.Uint8("boxType", boxType),
+			zap.Uint8("boxIndex", boxIndex),
+		)
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (w *Warehouse) saveBox(s *Session, boxType uint8, boxIndex uint8, data []byte) error {
+	_, err := s.server.db.Exec(`
+		INSERT INTO warehouse_boxes (character_id, box_type, box_index, data) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (character_id, box_type, box_index) DO UPDATE SET data = excluded.data
+	`, w.CharID, boxType, boxIndex, data)
+
+	if err != nil {
+		s.logger.Error(
+			"failed to save warehouse box",
+			zap.Error(err),
+			zap.Uint32("charID", w.CharID),
+			zap.Uint8("boxType", boxType),
+			zap.Uint8("boxIndex", boxIndex),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (w *Warehouse) Items(s *Session, boxIndex uint8) ([]*mhfpacket.WarehouseItem, error) {
+	data, err := w.loadBox(s, WarehouseBoxItems, boxIndex)
+
+	if err != nil {
+		return nil, err
+	}
+
+	bf := byteframe.NewByteFrameFromBytes(data)
+	items := make([]*mhfpacket.WarehouseItem, len(data)/mhfpacket.WarehouseItemSize)
+
+	for i := range items {
+		items[i] = mhfpacket.ReadWarehouseItem(bf)
+	}
+
+	return items, nil
+}
+
+func (w *Warehouse) Equipment(s *Session, boxIndex uint8) ([]*mhfpacket.WarehouseEquipment, error) {
+	data, err := w.loadBox(s, WarehouseBoxEquipment, boxIndex)
+
+	if err != nil {
+		return nil, err
+	}
+
+	bf := byteframe.NewByteFrameFromBytes(data)
+	equipment := make([]*mhfpacket.WarehouseEquipment, len(data)/mhfpacket.WarehouseEquipmentSize)
+
+	for i := range equipment {
+		equipment[i] = mhfpacket.ReadWarehouseEquipment(bf)
+	}
+
+	return equipment, nil
+}
+
+// UpdateItems applies the changed stacks sent by the client to an item box.
+func (w *Warehouse) UpdateItems(s *Session, boxIndex uint8, changes []*mhfpacket.WarehouseItem) error {
+	if !w.IsUnlocked(WarehouseBoxItems, boxIndex) {
+		return ErrWarehouseBoxLocked
+	}
+
+	items, err := w.Items(s, boxIndex)
+
+	if err != nil {
+		return err
+	}
+
+	var nextID uint32
+
+	for _, item := range items {
+		if item.WarehouseID > nextID {
+			nextID = item.WarehouseID
+		}
+	}
+
+	for _, change := range changes {
+		if change.Quantity > WarehouseMaxStackSize {
+			return fmt.Errorf("stack of %d exceeds the maximum stack size", change.Quantity)
+		}
+
+		found := false
+
+		for _, item := range items {
+			if change.WarehouseID != 0 && item.WarehouseID == change.WarehouseID {
+				item.ItemID = change.ItemID
+				item.Quantity = change.Quantity
+				found = true
+				break
+			}
+		}
+
+		if !found && change.Quantity > 0 {
+			nextID++
+			change.WarehouseID = nextID
+			items = append(items, change)
+		}
+	}
+
+	bf := byteframe.NewByteFrame()
+	count := 0
+
+	for _, item := range items {
+		if item.Quantity == 0 {
+			continue
+		}
+
+		item.Write(bf)
+		count++
+	}
+
+	if count > WarehouseBoxCapacity {
+		return fmt.Errorf("%d stacks exceed the box capacity", count)
+	}
+
+	return w.saveBox(s, WarehouseBoxItems, boxIndex, bf.Data())
+}
+
+// UpdateEquipment applies the changed equipment sent by the client to an equipment box.
+func (w *Warehouse) UpdateEquipment(s *Session, boxIndex uint8, changes []*mhfpacket.WarehouseEquipment) error {
+	if !w.IsUnlocked(WarehouseBoxEquipment, boxIndex) {
+		return ErrWarehouseBoxLocked
+	}
+
+	equipment, err := w.Equipment(s, boxIndex)
+
+	if err != nil {
+		return err
+	}
+
+	var nextID uint32
+
+	for _, e := range equipment {
+		if e.WarehouseID > nextID {
+			nextID = e.WarehouseID
+		}
+	}
+
+	for _, change := range changes {
+		found := false
+
+		for i, e := range equipment {
+			if change.WarehouseID != 0 && e.WarehouseID == change.WarehouseID {
+				equipment[i] = change
+				found = true
+				break
+			}
+		}
+
+		if !found && change.ItemID != 0 {
+			nextID++
+			change.WarehouseID = nextID
+			equipment = append(equipment, change)
+		}
+	}
+
+	bf := byteframe.NewByteFrame()
+	count := 0
+
+	for _, e := range equipment {
+		if e.ItemID == 0 {
+			continue
+		}
+
+		e.Write(bf)
+		count++
+	}
+
+	if count > WarehouseBoxCapacity {
+		return fmt.Errorf("%d pieces of equipment exceed the box capacity", count)
+	}
+
+	return w.saveBox(s, WarehouseBoxEquipment, boxIndex, bf.Data())
+}
@@ -0,0 +1,49 @@
+package channelserver
+
+import (
+	"testing"
+)
+
+func TestWarehouseIsUnlocked(t *testing.T) {
+	warehouse := &Warehouse{ItemPages: 3, EquipPages: 1}
+
+	tests := []struct {
+		boxType  uint8
+		boxIndex uint8
+		want     bool
+	}{
+		{WarehouseBoxItems, 0, true},
+		{WarehouseBoxItems, 2, true},
+		{WarehouseBoxItems, 3, false},
+		{WarehouseBoxEquipment, 0, true},
+		{WarehouseBoxEquipment, 1, false},
+		{WarehouseBoxEquipment + 1, 0, false},
+	}
+
+	for _, tt := range tests {
+		if got := warehouse.IsUnlocked(tt.boxType, tt.boxIndex); got != tt.want {
+			t.Errorf("IsUnlocked(%d, %d) = %v, want %v", tt.boxType, tt.boxIndex, got, tt.want)
+		}
+	}
+}
+
+func TestWarehouseBoxName(t *testing.T) {
+	warehouse := &Warehouse{ItemNames: []string{"Potions", ""}, EquipNames: []string{}}
+
+	tests := []struct {
+		boxType  uint8
+		boxIndex uint8
+		want     string
+	}{
+		{WarehouseBoxItems, 0, "Potions"},
+		{WarehouseBoxItems, 1, ""},
+		{WarehouseBoxItems, 9, ""},
+		{WarehouseBoxEquipment, 0, ""},
+	}
+
+	for _, tt := range tests {
+		if got := warehouse.BoxName(tt.boxType, tt.boxIndex); got != tt.want {
+			t.Errorf("BoxName(%d, %d) = %q, want %q", tt.boxType, tt.boxIndex, got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package signserver
 
-import "time"
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
 
 func (s *Server) registerDBAccount(username string, password string) error {
 	_, err := s.db.Exec("INSERT INTO users (username, password) VALUES ($1, $2)", username, password)
@@ -54,3 +58,31 @@ func (s *Server) getCharactersForUser(uid int) ([]character, error) {
 	}
 	return characters, nil
 }
+
+// Friend of one of the user's characters.
+type friend struct {
+	CharID uint32 `db:"character_id"` // Character whose friends list the friend is on
+	ID     uint32 `db:"id"`
+	Name   string `db:"name"`
+}
+
+func (s *Server) getFriendsForCharacters(chars []character) ([]friend, error) {
+	charIDs := make([]uint32, len(chars))
+
+	for i, char := range chars {
+		charIDs[i] = char.ID
+	}
+
+	friends := []friend{}
+	err := s.db.Select(&friends, `
+		SELECT cf.character_id, c.id, c.name FROM character_friends cf
+			JOIN characters c ON c.id = cf.friend_id
+		WHERE cf.character_id = ANY($1)
+		ORDER BY cf.character_id, cf.created_at
+		LIMIT 255
+	`, pq.Array(charIDs))
+	if err != nil {
+		return nil, err
+	}
+	return friends, nil
+}
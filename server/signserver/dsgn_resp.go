@@ -3,6 +3,7 @@ package signserver
 import (
 	"fmt"
 
+	"github.com/Andoryuuta/Erupe/common/stringsupport"
 	"github.com/Andoryuuta/byteframe"
 	"go.uber.org/zap"
 )
@@ -80,7 +81,18 @@ func (s *Session) makeSignInResp(uid int) []byte {
 		}
 	}
 
-	bf.WriteUint8(0)           // friends_list_count
+	friends, err := s.server.getFriendsForCharacters(chars)
+	if err != nil {
+		s.logger.Warn("Error getting friends from DB", zap.Error(err))
+	}
+
+	bf.WriteUint8(uint8(len(friends))) // friends_list_count
+	for _, friend := range friends {
+		bf.WriteUint32(friend.CharID)
+		bf.WriteUint32(friend.ID)
+		uint8PascalString(bf, stringsupport.MustConvertUTF8ToShiftJIS(friend.Name))
+	}
+
 	bf.WriteUint8(0)           // guild_members_count
 	bf.WriteUint8(0)           // notice_count
 	bf.WriteUint32(0xDEADBEEF) // some_last_played_character_id